@@ -4,11 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/cometbft/cometbft/abci/types"
-	"github.com/google/uuid"
 	ptypes "github.com/jclee286/politisian/pkg/types"
 )
 
@@ -24,50 +22,26 @@ func (app *PoliticianApp) Info(_ context.Context, req *types.RequestInfo) (*type
 // Query queries the application state.
 func (app *PoliticianApp) Query(_ context.Context, req *types.RequestQuery) (*types.ResponseQuery, error) {
 	app.logger.Info("Received Query", "path", req.Path, "data", string(req.Data))
-	switch req.Path {
-	case "/github.com/jclee286/politisian/list":
-		res, err := json.Marshal(app.politicians)
-		if err != nil {
-			return &types.ResponseQuery{Code: 4, Log: "failed to marshal politicians list"}, nil
-		}
-		return &types.ResponseQuery{Value: res}, nil
-	case "/proposals/list":
-		res, err := json.Marshal(app.proposals)
-		if err != nil {
-			return &types.ResponseQuery{Code: 4, Log: "failed to marshal proposals list"}, nil
-		}
-		return &types.ResponseQuery{Value: res}, nil
-	default:
-		// Handle account queries with pattern /account?address=...
-		if len(req.Path) >= 8 && req.Path[:8] == "/account" {
-			// Extract address from query string
-			address := ""
-			if len(req.Path) > 16 && req.Path[8:16] == "?address" {
-				address = req.Path[17:] // Skip "?address="
-			}
-			
-			if address == "" {
-				return &types.ResponseQuery{Code: 2, Log: "address parameter required"}, nil
-			}
-			
-			account, exists := app.accounts[address]
-			if !exists {
-				return &types.ResponseQuery{Code: 3, Log: "account not found"}, nil
-			}
-			
-			res, err := json.Marshal(account)
-			if err != nil {
-				return &types.ResponseQuery{Code: 4, Log: "failed to marshal account"}, nil
-			}
-			return &types.ResponseQuery{Value: res}, nil
-		}
-		return &types.ResponseQuery{Code: 1, Log: "unknown query path"}, nil
-	}
+	return app.routeQuery(req), nil
 }
 
 // CheckTx validates a transaction for the mempool.
 func (app *PoliticianApp) CheckTx(_ context.Context, req *types.RequestCheckTx) (*types.ResponseCheckTx, error) {
-	app.logger.Debug("Received CheckTx", "tx", string(req.Tx))
+	app.logger.Debug("Received CheckTx", "tx", fmt.Sprintf("%X", req.Tx))
+	// 트랜잭션이 다음 블록에 들어간다고 보고 FinalizeBlock과 같은 높이로 검증합니다.
+	// 다음 블록의 시간은 아직 모르므로 마지막 블록의 시간을 그대로 씁니다.
+	app.exec = execContext{height: app.height + 1, time: app.exec.time}
+	signed, txData, route, txErr := decodeTx(req.Tx, app.chainID)
+	if txErr == nil {
+		txErr = app.authenticateTx(signed, txData)
+	}
+	if txErr == nil {
+		txErr = route.validate(app, txData)
+	}
+	if txErr != nil {
+		app.logger.Info("Rejected tx in CheckTx", "code", txErr.Code, "log", txErr.Log)
+		return txErr.checkResult(), nil
+	}
 	return &types.ResponseCheckTx{Code: types.CodeTypeOK}, nil
 }
 
@@ -79,6 +53,7 @@ func (app *PoliticianApp) Commit(_ context.Context, _ *types.RequestCommit) (*ty
 		panic(err)
 	}
 	app.logger.Info("Committed state", "height", app.height, "appHash", fmt.Sprintf("%X", app.appHash))
+	app.maybeSnapshot()
 	return &types.ResponseCommit{}, nil
 }
 
@@ -90,14 +65,19 @@ func (app *PoliticianApp) InitChain(_ context.Context, req *types.RequestInitCha
 		app.logger.Error("Failed to parse genesis app state", "error", err)
 		return nil, fmt.Errorf("failed to parse genesis state: %w", err)
 	}
-	if genesisState.Politicians != nil {
-		app.politicians = genesisState.Politicians
-		app.logger.Info("Loaded politicians from genesis", "count", len(app.politicians))
+	if app.chainID != "" && app.chainID != req.ChainId {
+		return nil, fmt.Errorf("genesis chain ID %q does not match the node chain ID %q", req.ChainId, app.chainID)
+	}
+	app.chainID = req.ChainId
+	// export-genesis로 이어 가는 체인은 1보다 큰 높이에서 시작합니다.
+	if req.InitialHeight > 1 {
+		app.height = req.InitialHeight - 1
 	}
-	if genesisState.Accounts != nil {
-		app.accounts = genesisState.Accounts
-		app.logger.Info("Loaded accounts from genesis", "count", len(app.accounts))
+	if err := app.initGenesis(&genesisState); err != nil {
+		app.logger.Error("Failed to load genesis state", "error", err)
+		return nil, err
 	}
+	app.touchAll()
 	return &types.ResponseInitChain{}, nil
 }
 
@@ -105,65 +85,52 @@ func (app *PoliticianApp) InitChain(_ context.Context, req *types.RequestInitCha
 func (app *PoliticianApp) FinalizeBlock(_ context.Context, req *types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
 	app.logger.Info("Finalizing block", "height", req.Height, "num_txs", len(req.Txs))
 	respTxs := make([]*types.ExecTxResult, len(req.Txs))
+	app.exec = execContext{height: req.Height, time: req.Time.Unix()}
 	for i, tx := range req.Txs {
-		var txData ptypes.TxData
-		if err := json.Unmarshal(tx, &txData); err != nil {
-			logMsg := "Failed to parse transaction data"
-			app.logger.Error(logMsg, "tx_raw", string(tx), "error", err)
-			respTxs[i] = &types.ExecTxResult{Code: 1, Log: logMsg}
+		app.exec.beginTx(i, tx)
+		signed, txData, route, txErr := decodeTx(tx, app.chainID)
+		if txErr != nil {
+			app.logger.Error(txErr.Log, "tx_raw", fmt.Sprintf("%X", tx))
+			respTxs[i] = txErr.execResult()
 			continue
 		}
 
 		app.logger.Info("Processing tx", "action", txData.Action, "user_id", txData.UserID)
-		switch txData.Action {
-		case "create_profile":
-			respTxs[i] = app.handleCreateProfile(&txData)
-		case "update_supporters":
-			respTxs[i] = app.updateSupporters(&txData)
-		case "propose_politician":
-			respTxs[i] = app.proposePolitician(&txData)
-		case "vote_on_proposal":
-			respTxs[i] = app.handleVoteOnProposal(&txData)
-		case "claim_referral_reward":
-			respTxs[i] = app.handleClaimReferralReward(&txData)
-		case "place_order":
-			respTxs[i] = app.handlePlaceOrder(&txData)
-		case "cancel_order":
-			respTxs[i] = app.handleCancelOrder(&txData)
-		case "freeze_escrow":
-			respTxs[i] = app.handleFreezeEscrow(&txData)
-		case "release_escrow":
-			respTxs[i] = app.handleReleaseEscrow(&txData)
-		case "execute_trade":
-			respTxs[i] = app.handleExecuteTrade(&txData)
-		case "deposit_stablecoin":
-			respTxs[i] = app.handleDepositStablecoin(&txData)
-		case "withdraw_stablecoin":
-			respTxs[i] = app.handleWithdrawStablecoin(&txData)
-		default:
-			logMsg := "Unknown action"
-			app.logger.Error(logMsg, "action", txData.Action)
-			respTxs[i] = &types.ExecTxResult{Code: 10, Log: logMsg}
+		if txErr := app.authenticateTx(signed, txData); txErr != nil {
+			app.logger.Info("Rejected unauthenticated tx", "action", txData.Action, "user_id", txData.UserID, "code", txErr.Code, "log", txErr.Log)
+			respTxs[i] = txErr.execResult()
+			continue
+		}
+		// CheckTx와 같은 검증을 블록 실행 시점의 상태로 다시 수행합니다.
+		if txErr := route.validate(app, txData); txErr != nil {
+			app.logger.Info("Rejected tx", "action", txData.Action, "user_id", txData.UserID, "code", txErr.Code, "log", txErr.Log)
+			respTxs[i] = txErr.execResult()
+		} else {
+			respTxs[i] = route.execute(app, txData)
 		}
+		// 인증된 트랜잭션은 실행 결과와 관계없이 nonce를 소모합니다.
+		app.consumeNonce(signed, txData)
+		// 서명자 계정은 nonce가 바뀌므로 항상 표시하고, 그 밖의 엔티티는 각 핸들러가 표시합니다.
+		app.touchAccount(txData.UserID)
 	}
 
+	// 모든 트랜잭션을 실행한 뒤 만료된 주문을 정리하고, 투표 기간이 끝난 제안을 집계합니다.
+	events := append(app.expireOrders(), app.tallyEndedProposals()...)
+
 	app.hashState() // Update app hash after all transactions
 	app.logger.Debug("Finalized block state", "appHash", fmt.Sprintf("%X", app.appHash))
 
 	return &types.ResponseFinalizeBlock{
+		Events:    events,
 		TxResults: respTxs,
 		AppHash:   app.appHash,
 	}, nil
 }
 
 // --- Handler Functions ---
+// 각 핸들러는 txRoutes에 등록된 검증 함수가 통과한 뒤에 호출됩니다.
+
 func (app *PoliticianApp) handleCreateProfile(txData *ptypes.TxData) *types.ExecTxResult {
-	if _, exists := app.accounts[txData.UserID]; exists {
-		logMsg := "User ID already exists"
-		app.logger.Info(logMsg, "user_id", txData.UserID)
-		return &types.ExecTxResult{Code: 2, Log: logMsg}
-	}
-	
 	// 새 계정 생성
 	newAccount := &ptypes.Account{
 		Address:          txData.UserID,
@@ -174,10 +141,12 @@ func (app *PoliticianApp) handleCreateProfile(txData *ptypes.TxData) *types.Exec
 		PoliticianCoins:  make(map[string]int64),  // 정치인별 코인 보유량
 		ReceivedCoins:    make(map[string]bool),   // 정치인별 코인 수령 여부
 		InitialSelection: false,                   // 초기 선택 아직 완료 안됨
-		USDTBalance:      0,                       // 초기 USDT 잔액 0 (사용자가 직접 입금)
-		USDCBalance:      0,                       // 초기 USDC 잔액 0 (사용자가 직접 입금)
+		USDTBalance:      0,                       // 초기 USDT 잔액 0 (등록 키가 기록한 입금으로 증가)
+		USDCBalance:      0,                       // 초기 USDC 잔액 0 (등록 키가 기록한 입금으로 증가)
 		MATICBalance:     0,                       // 초기 MATIC 잔액 0 (수수료용)
 		ActiveOrders:     []ptypes.TradeOrder{},   // 빈 주문 배열
+		CreatedHeight:    app.exec.height,         // 제안·투표 자격(최소 계정 나이) 계산용
+		PubKey:           append([]byte(nil), txData.Msg.CreateAccount.PubKey...), // 등록 키가 확인한 사용자 키
 		EscrowAccount: ptypes.EscrowAccount{       // 에스크로 계정 초기화
 			UserID:                txData.UserID,
 			FrozenUSDTBalance:     0,
@@ -194,6 +163,7 @@ func (app *PoliticianApp) handleCreateProfile(txData *ptypes.TxData) *types.Exec
 		// 추천인 계정 찾기
 		if referrerAccount, exists := app.accounts[txData.Referrer]; exists {
 			referrerAccount.ReferralCredits++
+			app.touchAccount(txData.Referrer)
 			app.logger.Info("Referral credit granted", "referrer", txData.Referrer, "new_credits", referrerAccount.ReferralCredits)
 		} else {
 			app.logger.Info("Referrer account not found", "referrer", txData.Referrer)
@@ -211,11 +181,17 @@ func (app *PoliticianApp) updateSupporters(txData *ptypes.TxData) *types.ExecTxR
 		"politicians", txData.Politicians,
 		"politician_count", len(txData.Politicians))
 	
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		logMsg := "Account not found for update"
-		app.logger.Info(logMsg, "user_id", txData.UserID)
-		return &types.ExecTxResult{Code: 30, Log: logMsg}
+	account := app.accounts[txData.UserID]
+	
+	// 요청의 정치인은 ID 또는 동명이인이 없는 이름이며, 계정에는 정식 ID로 저장합니다.
+	politicianIDs := make([]string, 0, len(txData.Politicians))
+	for _, ref := range txData.Politicians {
+		politician, matches := app.resolvePolitician(ref)
+		if politician == nil || politician.Delisted {
+			app.logger.Info("Politician not found, ambiguous or delisted", "ref", ref, "matches", matches)
+			continue
+		}
+		politicianIDs = append(politicianIDs, string(politician.ID))
 	}
 	
 	app.logger.Info("🔍 사용자 계정 확인",
@@ -233,36 +209,37 @@ func (app *PoliticianApp) updateSupporters(txData *ptypes.TxData) *types.ExecTxR
 			"user_id", txData.UserID,
 			"politician_count", len(txData.Politicians))
 		
-		for _, politicianName := range txData.Politicians {
-			app.logger.Info("🔄 정치인 처리 중", "name", politicianName, "user", txData.UserID)
+		for _, politicianID := range politicianIDs {
+			app.logger.Info("🔄 정치인 처리 중", "id", politicianID, "user", txData.UserID)
 			
 			// 이미 받은 코인인지 확인
-			if !account.ReceivedCoins[politicianName] {
+			if !account.ReceivedCoins[politicianID] {
 				// 정치인이 존재하고 코인이 충분한지 확인
-				if politician, exists := app.politicians[politicianName]; exists {
+				if politician, exists := app.politicians[politicianID]; exists {
 					if politician.RemainingCoins >= 100 {
 						// 코인 지급
-						account.PoliticianCoins[politicianName] += 100
-						account.ReceivedCoins[politicianName] = true
+						account.PoliticianCoins[politicianID] += 100
+						account.ReceivedCoins[politicianID] = true
 						
 						// 정치인의 남은 코인 수량 감소
 						politician.RemainingCoins -= 100
 						politician.DistributedCoins += 100
+						app.touchPolitician(politicianID)
 						
 						totalCoinsGiven += 100
 						
 						app.logger.Info("Initial coin distribution", 
 							"user", txData.UserID, 
-							"politician", politicianName,
+							"politician", politicianID,
 							"coins_given", 100,
 							"politician_remaining", politician.RemainingCoins)
 					} else {
 						app.logger.Info("Politician has insufficient coins", 
-							"politician", politicianName,
+							"politician", politicianID,
 							"remaining", politician.RemainingCoins)
 					}
 				} else {
-					app.logger.Info("Politician not found", "name", politicianName, "available_politicians", len(app.politicians))
+					app.logger.Info("Politician not found", "id", politicianID, "available_politicians", len(app.politicians))
 				}
 			}
 		}
@@ -274,118 +251,128 @@ func (app *PoliticianApp) updateSupporters(txData *ptypes.TxData) *types.ExecTxR
 			"politicians_processed", len(txData.Politicians))
 	}
 	
-	account.Politicians = txData.Politicians
+	account.Politicians = politicianIDs
 	app.logger.Info("Updated supporters", "user_id", txData.UserID, "politician_count", len(txData.Politicians))
 	return &types.ExecTxResult{Code: types.CodeTypeOK}
 }
 
 func (app *PoliticianApp) proposePolitician(txData *ptypes.TxData) *types.ExecTxResult {
-	proposalID := uuid.New().String()
-	app.proposals[proposalID] = &ptypes.Proposal{
-		ID: proposalID, Politician: ptypes.Politician{
-			Name: txData.PoliticianName, Region: txData.Region, Party: txData.Party, IntroUrl: txData.IntroUrl,
-		}, Proposer: txData.UserID, Votes: make(map[string]bool),
+	proposal := app.submitProposal(txData, ptypes.ProposalTypeRegister, ptypes.Politician{
+		ID:   ptypes.NewPoliticianID(txData.PoliticianName, txData.Region),
+		Name: strings.TrimSpace(txData.PoliticianName), Region: strings.TrimSpace(txData.Region),
+		Party: strings.TrimSpace(txData.Party), IntroUrl: strings.TrimSpace(txData.IntroUrl),
+	})
+	app.logger.Info("Proposed new politician", "proposer", txData.UserID, "politician_name", txData.PoliticianName, "proposal_id", proposal.ID, "voting_end_height", proposal.VotingEndHeight, "tally_mode", proposal.TallyMode)
+	return &types.ExecTxResult{Code: types.CodeTypeOK, Data: []byte(proposal.ID)}
+}
+
+// handleProposeAmendment는 정치인의 지역, 정당, 소개 URL을 바꾸는 제안을 만듭니다.
+func (app *PoliticianApp) handleProposeAmendment(txData *ptypes.TxData) *types.ExecTxResult {
+	target, _ := app.politicianByID(txData.Msg.ProposeAmendment.PoliticianID)
+	proposal := app.submitProposal(txData, ptypes.ProposalTypeAmend, amendedPolitician(target, txData.Msg.ProposeAmendment))
+	app.logger.Info("Proposed politician amendment", "proposer", txData.UserID, "politician_id", target.ID, "proposal_id", proposal.ID, "voting_end_height", proposal.VotingEndHeight)
+	return &types.ExecTxResult{Code: types.CodeTypeOK, Data: []byte(proposal.ID)}
+}
+
+// handleProposeDelisting은 정치인을 상장 폐지하는 제안을 만듭니다.
+func (app *PoliticianApp) handleProposeDelisting(txData *ptypes.TxData) *types.ExecTxResult {
+	target, _ := app.politicianByID(txData.Msg.ProposeDelisting.PoliticianID)
+	politician := *target
+	politician.Supporters = []string{}
+	proposal := app.submitProposal(txData, ptypes.ProposalTypeDelist, politician)
+	app.logger.Info("Proposed politician delisting", "proposer", txData.UserID, "politician_id", target.ID, "proposal_id", proposal.ID, "voting_end_height", proposal.VotingEndHeight)
+	return &types.ExecTxResult{Code: types.CodeTypeOK, Data: []byte(proposal.ID)}
+}
+
+// amendedPolitician은 수정 제안이 가결되었을 때의 정치인 정보입니다. 이름과 ID는 바뀌지 않습니다.
+func amendedPolitician(target *ptypes.Politician, msg *ptypes.ProposeAmendmentMsg) ptypes.Politician {
+	return ptypes.Politician{
+		ID: target.ID, Name: target.Name,
+		Region: strings.TrimSpace(msg.Region), Party: strings.TrimSpace(msg.Party), IntroUrl: strings.TrimSpace(msg.IntroUrl),
 	}
-	app.logger.Info("Proposed new politician", "proposer", txData.UserID, "politician_name", txData.PoliticianName, "proposal_id", proposalID)
-	return &types.ExecTxResult{Code: types.CodeTypeOK}
+}
+
+// submitProposal은 제안자의 보증금을 맡기고 투표 중인 제안을 만듭니다.
+func (app *PoliticianApp) submitProposal(txData *ptypes.TxData, proposalType string, politician ptypes.Politician) *ptypes.Proposal {
+	proposalID := app.exec.newID("proposal")
+	// 보증금은 USDT 잔액에서 빼서 제안에 보관하고, 집계 때 돌려주거나 소각합니다.
+	deposit := app.govParams.ProposerDeposit
+	app.accounts[txData.UserID].USDTBalance -= deposit
+	app.touchAccount(txData.UserID)
+	tallyMode := txData.TallyMode
+	if tallyMode == "" {
+		tallyMode = ptypes.TallyModeOneAccountOneVote
+	}
+	proposal := &ptypes.Proposal{
+		ID:              proposalID,
+		Type:            proposalType,
+		Politician:      politician,
+		Proposer:        txData.UserID,
+		Votes:           make(map[string]bool),
+		SubmitHeight:    app.exec.height,
+		VotingEndHeight: app.exec.height + app.govParams.VotingPeriod,
+		Deposit:         deposit,
+		TallyMode:       tallyMode,
+		Status:          ptypes.ProposalStatusVoting,
+	}
+	app.proposals[proposalID] = proposal
+	app.touchProposal(proposalID)
+	return proposal
 }
 
 func (app *PoliticianApp) handleVoteOnProposal(txData *ptypes.TxData) *types.ExecTxResult {
-	proposal, exists := app.proposals[txData.ProposalID]
-	if !exists {
-		logMsg := "Proposal not found for vote"
-		app.logger.Info(logMsg, "proposal_id", txData.ProposalID)
-		return &types.ExecTxResult{Code: 40, Log: logMsg}
-	}
-	if _, alreadyVoted := proposal.Votes[txData.UserID]; alreadyVoted {
-		logMsg := "User has already voted"
-		app.logger.Info(logMsg, "user_id", txData.UserID, "proposal_id", txData.ProposalID)
-		return &types.ExecTxResult{Code: 41, Log: logMsg}
-	}
+	proposal := app.proposals[txData.ProposalID]
 	proposal.Votes[txData.UserID] = txData.Vote
+	app.touchProposal(txData.ProposalID)
 	if txData.Vote {
 		proposal.YesVotes++
 	} else {
 		proposal.NoVotes++
 	}
 	app.logger.Info("Vote cast", "user_id", txData.UserID, "proposal_id", txData.ProposalID, "vote", txData.Vote, "yes_votes", proposal.YesVotes, "no_votes", proposal.NoVotes)
-
-	if proposal.YesVotes >= 1 {
-		newPolitician := &proposal.Politician
-		
-		// 정치인 등록 시 1,000만개 코인 발행
-		newPolitician.TotalCoinSupply = 10_000_000    // 1,000만개
-		newPolitician.RemainingCoins = 10_000_000     // 초기에는 모두 남아있음
-		newPolitician.DistributedCoins = 0            // 아직 배포 안됨
-		
-		app.politicians[newPolitician.Name] = newPolitician
-		delete(app.proposals, txData.ProposalID)
-		app.logger.Info("Politician approved with coin issuance", 
-			"proposal_id", txData.ProposalID, 
-			"politician_name", newPolitician.Name,
-			"coin_supply", newPolitician.TotalCoinSupply)
-	}
+	// 가결 여부는 투표 기간이 끝나는 블록에서 tallyEndedProposals가 정합니다.
 	return &types.ExecTxResult{Code: types.CodeTypeOK}
 }
 
+// handleWithdrawProposal은 제안자가 투표 중인 제안을 철회합니다.
+// 철회한 제안의 보증금은 만료된 제안과 같이 소각하므로, 부결을 피하려고 철회해도 얻는 것이 없습니다.
+func (app *PoliticianApp) handleWithdrawProposal(txData *ptypes.TxData) *types.ExecTxResult {
+	proposal := app.proposals[txData.Msg.WithdrawProposal.ProposalID]
+	proposal.Status = ptypes.ProposalStatusWithdrawn
+	proposal.ClosedHeight = app.exec.height
+	app.touchProposal(proposal.ID)
+	app.logger.Info("Proposal withdrawn", "proposal_id", proposal.ID, "proposer", txData.UserID, "deposit_burned", proposal.Deposit)
+	return &types.ExecTxResult{Code: types.CodeTypeOK, Events: []types.Event{{
+		Type: "proposal_withdrawn",
+		Attributes: []types.EventAttribute{
+			{Key: "proposal_id", Value: proposal.ID, Index: true},
+			{Key: "politician_id", Value: proposal.Politician.ID.String(), Index: true},
+			{Key: "deposit", Value: "burned"},
+		},
+	}}}
+}
+
 // handleClaimReferralReward는 추천 크레딧을 사용하여 새 정치인의 코인 100개를 지급합니다.
 func (app *PoliticianApp) handleClaimReferralReward(txData *ptypes.TxData) *types.ExecTxResult {
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		logMsg := "Account not found for referral reward claim"
-		app.logger.Info(logMsg, "user_id", txData.UserID)
-		return &types.ExecTxResult{Code: 50, Log: logMsg}
-	}
-	
-	// 사용 가능한 크레딧이 있는지 확인
-	if account.ReferralCredits <= 0 {
-		logMsg := "No referral credits available"
-		app.logger.Info(logMsg, "user_id", txData.UserID, "credits", account.ReferralCredits)
-		return &types.ExecTxResult{Code: 51, Log: logMsg}
-	}
-	
-	// 선택한 정치인이 존재하는지 확인
-	if txData.PoliticianName == "" {
-		logMsg := "No politician specified for referral reward"
-		app.logger.Info(logMsg, "user_id", txData.UserID)
-		return &types.ExecTxResult{Code: 52, Log: logMsg}
-	}
-	
-	// 이미 받은 정치인인지 확인
-	if account.ReceivedCoins[txData.PoliticianName] {
-		logMsg := "Already received coins from this politician"
-		app.logger.Info(logMsg, "user_id", txData.UserID, "politician", txData.PoliticianName)
-		return &types.ExecTxResult{Code: 53, Log: logMsg}
-	}
-	
-	// 정치인이 존재하고 코인이 충분한지 확인
-	politician, exists := app.politicians[txData.PoliticianName]
-	if !exists {
-		logMsg := "Politician not found"
-		app.logger.Info(logMsg, "politician", txData.PoliticianName)
-		return &types.ExecTxResult{Code: 54, Log: logMsg}
-	}
-	
-	if politician.RemainingCoins < 100 {
-		logMsg := "Not enough coins available from politician"
-		app.logger.Info(logMsg, "politician", txData.PoliticianName, "remaining", politician.RemainingCoins)
-		return &types.ExecTxResult{Code: 55, Log: logMsg}
-	}
+	account := app.accounts[txData.UserID]
+	politician, _ := app.resolvePolitician(txData.PoliticianName)
+	politicianID := string(politician.ID)
 	
 	// 크레딧 1개 차감
 	account.ReferralCredits--
 	
 	// 코인 지급
-	account.PoliticianCoins[txData.PoliticianName] += 100
-	account.ReceivedCoins[txData.PoliticianName] = true
+	account.PoliticianCoins[politicianID] += 100
+	account.ReceivedCoins[politicianID] = true
 	
 	// 정치인의 남은 코인 수량 감소
 	politician.RemainingCoins -= 100
 	politician.DistributedCoins += 100
+	app.touchPolitician(politicianID)
 	
 	app.logger.Info("Referral reward coin distributed", 
 		"user", txData.UserID, 
-		"politician", txData.PoliticianName,
+		"politician", politicianID,
 		"coins_given", 100,
 		"remaining_credits", account.ReferralCredits,
 		"politician_remaining", politician.RemainingCoins)
@@ -399,288 +386,99 @@ func (app *PoliticianApp) handleClaimReferralReward(txData *ptypes.TxData) *type
 func (app *PoliticianApp) handlePlaceOrder(txData *ptypes.TxData) *types.ExecTxResult {
 	app.logger.Info("Processing place order", "user_id", txData.UserID, "tx_id", txData.TxID)
 	
-	// 주문 데이터 파싱
-	if len(txData.Politicians) == 0 {
-		return &types.ExecTxResult{Code: 1, Log: "주문 데이터가 없습니다"}
-	}
-	
-	var order ptypes.TradeOrder
-	if err := json.Unmarshal([]byte(txData.Politicians[0]), &order); err != nil {
-		app.logger.Error("Failed to parse order data", "error", err)
-		return &types.ExecTxResult{Code: 2, Log: "주문 데이터 파싱 실패"}
-	}
-	
-	// 계정 확인
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		return &types.ExecTxResult{Code: 3, Log: "계정을 찾을 수 없습니다"}
-	}
+	order := newOrderFromMsg(txData.Msg.PlaceOrder)
+	account := app.accounts[txData.UserID]
 	
 	// 에스크로 계정 초기화
-	if account.EscrowAccount.FrozenPoliticianCoins == nil {
-		account.EscrowAccount.FrozenPoliticianCoins = make(map[string]int64)
-	}
-	if account.EscrowAccount.ActiveOrders == nil {
-		account.EscrowAccount.ActiveOrders = []string{}
-	}
-	
-	// 주문을 전역 주문 맵에 저장
-	app.orders[order.ID] = &order
-	
-	app.logger.Info("Order placed successfully", "order_id", order.ID, "type", order.OrderType, "quantity", order.Quantity, "price", order.Price)
-	return &types.ExecTxResult{Code: types.CodeTypeOK}
+	ensureEscrowAccount(account)
+	
+	// 주문 ID, 소유자, 접수 순서와 시간은 체인에서 결정합니다.
+	app.orderSequence++
+	order.ID = app.exec.newID("order")
+	order.UserID = txData.UserID
+	order.CreatedAt = app.exec.time
+	order.UpdatedAt = app.exec.time
+	order.Sequence = app.orderSequence
+	order.Status = "active"
+	app.dirty[orderSequenceKey] = true
+	
+	// 시장가 주문의 체결 한계 가격은 검증 때와 같은 오더북으로 정합니다.
+	if order.Kind == ptypes.OrderKindMarket {
+		app.priceMarketOrder(order)
+	}
+	
+	// 전량 즉시 체결할 수 없는 FOK 주문은 에스크로를 잡지 않고 아무것도 체결하지 않은 채 종료합니다.
+	if order.TimeInForce == ptypes.TimeInForceFOK && app.fillableQuantity(order) < order.Quantity {
+		order.Status = "killed"
+		app.orders[order.ID] = order
+		app.touchOrder(order.ID)
+		app.indexOrder(order)
+		app.logger.Info("FOK order killed", "order_id", order.ID, "quantity", order.Quantity)
+		events := []types.Event{orderPlacedEvent(order), orderClosedEvent(order)}
+		return &types.ExecTxResult{Code: types.CodeTypeOK, Data: []byte(order.ID), Log: order.Status, Events: events}
+	}
+	
+	// 잔액은 검증 단계에서 확인했으므로 주문 전체에 필요한 에스크로를 접수와 같은 트랜잭션에서 동결합니다.
+	// 체결되는 만큼 settleTrade가 동결을 풀고, 취소되거나 모두 체결되면 남은 동결이 해제됩니다.
+	lockOrderEscrow(account, order)
+	app.touchAccount(txData.UserID)
+	
+	// 주문을 전역 주문 맵과 오더북 색인에 저장
+	app.orders[order.ID] = order
+	app.touchOrder(order.ID)
+	app.indexOrder(order)
+	
+	app.logger.Info("Order placed successfully", "order_id", order.ID, "type", order.OrderType, "kind", order.Kind, "time_in_force", order.TimeInForce, "quantity", order.Quantity, "price", order.Price)
+	
+	// 오더북과 매칭하여 체결 가능한 만큼 체결
+	// 클라이언트가 주문 ID와 실행 후 상태를 알 수 있도록 결과 데이터(ID), 로그(상태), 이벤트로 돌려줍니다.
+	events := append([]types.Event{orderPlacedEvent(order)}, app.matchOrder(order)...)
+	
+	// GTC가 아닌 주문은 오더북에 남지 않으므로 체결되지 않은 나머지를 취소하고 에스크로를 해제합니다.
+	if order.TimeInForce != ptypes.TimeInForceGTC && isOpenOrder(order) {
+		app.cancelOrder(order, app.exec.time)
+		events = append(events, orderClosedEvent(order))
+	}
+	return &types.ExecTxResult{Code: types.CodeTypeOK, Data: []byte(order.ID), Log: order.Status, Events: events}
 }
 
 // handleCancelOrder는 주문 취소를 처리합니다.
 func (app *PoliticianApp) handleCancelOrder(txData *ptypes.TxData) *types.ExecTxResult {
 	app.logger.Info("Processing cancel order", "user_id", txData.UserID, "tx_id", txData.TxID)
 	
-	if len(txData.Politicians) == 0 {
-		return &types.ExecTxResult{Code: 1, Log: "주문 ID가 없습니다"}
-	}
-	
-	orderID := txData.Politicians[0]
-	order, exists := app.orders[orderID]
-	if !exists {
-		return &types.ExecTxResult{Code: 2, Log: "주문을 찾을 수 없습니다"}
-	}
+	orderID := txData.Msg.CancelOrder.OrderID
+	order := app.orders[orderID]
 	
-	// 주문 소유권 확인
-	if order.UserID != txData.UserID {
-		return &types.ExecTxResult{Code: 3, Log: "주문을 취소할 권한이 없습니다"}
-	}
-	
-	// 주문 상태 업데이트
-	order.Status = "cancelled"
+	// 주문 상태 업데이트 및 남은 에스크로 해제
+	app.cancelOrder(order, app.exec.time)
 	
 	app.logger.Info("Order cancelled successfully", "order_id", orderID, "user_id", txData.UserID)
 	return &types.ExecTxResult{Code: types.CodeTypeOK}
 }
 
-// handleFreezeEscrow는 에스크로 동결을 처리합니다.
-func (app *PoliticianApp) handleFreezeEscrow(txData *ptypes.TxData) *types.ExecTxResult {
-	app.logger.Info("Processing freeze escrow", "user_id", txData.UserID, "tx_id", txData.TxID)
-	
-	// 주문 데이터 파싱
-	if len(txData.Politicians) == 0 {
-		return &types.ExecTxResult{Code: 1, Log: "주문 데이터가 없습니다"}
-	}
-	
-	var order ptypes.TradeOrder
-	if err := json.Unmarshal([]byte(txData.Politicians[0]), &order); err != nil {
-		app.logger.Error("Failed to parse order data", "error", err)
-		return &types.ExecTxResult{Code: 2, Log: "주문 데이터 파싱 실패"}
-	}
-	
-	// 계정 확인
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		return &types.ExecTxResult{Code: 3, Log: "계정을 찾을 수 없습니다"}
-	}
-	
-	// 에스크로 계정 초기화
-	if account.EscrowAccount.FrozenPoliticianCoins == nil {
-		account.EscrowAccount.FrozenPoliticianCoins = make(map[string]int64)
-	}
-	if account.EscrowAccount.ActiveOrders == nil {
-		account.EscrowAccount.ActiveOrders = []string{}
-	}
-	
-	// 자금 동결
-	if order.OrderType == "buy" {
-		// 매수: 테더코인 동결
-		account.EscrowAccount.FrozenUSDTBalance += order.EscrowAmount
-		if account.USDTBalance < account.EscrowAccount.FrozenUSDTBalance {
-			return &types.ExecTxResult{Code: 4, Log: "테더코인 잔액이 부족합니다"}
-		}
-	} else {
-		// 매도: 정치인 코인 동결
-		account.EscrowAccount.FrozenPoliticianCoins[order.PoliticianID] += order.EscrowAmount
-		if account.PoliticianCoins[order.PoliticianID] < account.EscrowAccount.FrozenPoliticianCoins[order.PoliticianID] {
-			return &types.ExecTxResult{Code: 4, Log: "정치인 코인이 부족합니다"}
-		}
-	}
-	
-	// 활성 주문 목록에 추가
-	account.EscrowAccount.ActiveOrders = append(account.EscrowAccount.ActiveOrders, order.ID)
-	
-	app.logger.Info("Escrow frozen successfully", "order_id", order.ID, "amount", order.EscrowAmount, "type", order.OrderType)
-	return &types.ExecTxResult{Code: types.CodeTypeOK}
-}
-
 // handleReleaseEscrow는 에스크로 해제를 처리합니다.
 func (app *PoliticianApp) handleReleaseEscrow(txData *ptypes.TxData) *types.ExecTxResult {
 	app.logger.Info("Processing release escrow", "user_id", txData.UserID, "tx_id", txData.TxID)
 	
-	if len(txData.Politicians) == 0 {
-		return &types.ExecTxResult{Code: 1, Log: "주문 ID가 없습니다"}
-	}
-	
-	orderID := txData.Politicians[0]
-	order, exists := app.orders[orderID]
-	if !exists {
-		return &types.ExecTxResult{Code: 2, Log: "주문을 찾을 수 없습니다"}
-	}
-	
-	// 계정 확인
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		return &types.ExecTxResult{Code: 3, Log: "계정을 찾을 수 없습니다"}
-	}
-	
-	// 에스크로 해제
-	if order.OrderType == "buy" {
-		// 매수: 테더코인 해제
-		account.EscrowAccount.FrozenUSDTBalance -= order.EscrowAmount
-		if account.EscrowAccount.FrozenUSDTBalance < 0 {
-			account.EscrowAccount.FrozenUSDTBalance = 0
-		}
-	} else {
-		// 매도: 정치인 코인 해제
-		account.EscrowAccount.FrozenPoliticianCoins[order.PoliticianID] -= order.EscrowAmount
-		if account.EscrowAccount.FrozenPoliticianCoins[order.PoliticianID] < 0 {
-			account.EscrowAccount.FrozenPoliticianCoins[order.PoliticianID] = 0
-		}
-	}
+	orderID := txData.Msg.ReleaseEscrow.OrderID
+	order := app.orders[orderID]
+	account := app.accounts[txData.UserID]
 	
-	// 활성 주문 목록에서 제거
-	for i, activeOrderID := range account.EscrowAccount.ActiveOrders {
-		if activeOrderID == orderID {
-			account.EscrowAccount.ActiveOrders = append(account.EscrowAccount.ActiveOrders[:i], account.EscrowAccount.ActiveOrders[i+1:]...)
-			break
-		}
-	}
+	// 남은 에스크로 해제 (이미 해제된 경우 아무것도 하지 않음)
+	ensureEscrowAccount(account)
+	app.releaseOrderEscrow(account, order)
 	
 	app.logger.Info("Escrow released successfully", "order_id", orderID, "amount", order.EscrowAmount)
 	return &types.ExecTxResult{Code: types.CodeTypeOK}
 }
 
-// handleExecuteTrade는 거래 체결을 처리합니다.
-func (app *PoliticianApp) handleExecuteTrade(txData *ptypes.TxData) *types.ExecTxResult {
-	app.logger.Info("Processing execute trade", "tx_id", txData.TxID)
-	
-	// 거래 데이터 파싱
-	if len(txData.Politicians) == 0 {
-		return &types.ExecTxResult{Code: 1, Log: "거래 데이터가 없습니다"}
-	}
-	
-	var trade ptypes.Trade
-	if err := json.Unmarshal([]byte(txData.Politicians[0]), &trade); err != nil {
-		app.logger.Error("Failed to parse trade data", "error", err)
-		return &types.ExecTxResult{Code: 2, Log: "거래 데이터 파싱 실패"}
-	}
-	
-	// 매수자와 매도자 계정 확인
-	buyerAccount, buyerExists := app.accounts[trade.BuyerID]
-	sellerAccount, sellerExists := app.accounts[trade.SellerID]
-	
-	if !buyerExists || !sellerExists {
-		return &types.ExecTxResult{Code: 3, Log: "거래 당사자 계정을 찾을 수 없습니다"}
-	}
-	
-	// 주문 확인
-	buyOrder, buyOrderExists := app.orders[trade.BuyOrderID]
-	sellOrder, sellOrderExists := app.orders[trade.SellOrderID]
-	
-	if !buyOrderExists || !sellOrderExists {
-		return &types.ExecTxResult{Code: 4, Log: "거래 주문을 찾을 수 없습니다"}
-	}
-	
-	// 실제 자금 이체 수행
-	// 1. 매수자에게서 테더코인 차감 및 정치인 코인 지급
-	buyerAccount.USDTBalance -= trade.TotalAmount
-	if buyerAccount.PoliticianCoins == nil {
-		buyerAccount.PoliticianCoins = make(map[string]int64)
-	}
-	buyerAccount.PoliticianCoins[trade.PoliticianID] += trade.Quantity
-	
-	// 2. 매도자에게서 정치인 코인 차감 및 테더코인 지급
-	sellerAccount.PoliticianCoins[trade.PoliticianID] -= trade.Quantity
-	sellerAccount.USDTBalance += trade.TotalAmount
-	
-	// 3. 에스크로 해제
-	// 매수자 에스크로 해제
-	buyerAccount.EscrowAccount.FrozenUSDTBalance -= trade.TotalAmount
-	if buyerAccount.EscrowAccount.FrozenUSDTBalance < 0 {
-		buyerAccount.EscrowAccount.FrozenUSDTBalance = 0
-	}
-	
-	// 매도자 에스크로 해제
-	if sellerAccount.EscrowAccount.FrozenPoliticianCoins == nil {
-		sellerAccount.EscrowAccount.FrozenPoliticianCoins = make(map[string]int64)
-	}
-	sellerAccount.EscrowAccount.FrozenPoliticianCoins[trade.PoliticianID] -= trade.Quantity
-	if sellerAccount.EscrowAccount.FrozenPoliticianCoins[trade.PoliticianID] < 0 {
-		sellerAccount.EscrowAccount.FrozenPoliticianCoins[trade.PoliticianID] = 0
-	}
-	
-	// 4. 주문 상태 업데이트
-	buyOrder.FilledQuantity += trade.Quantity
-	sellOrder.FilledQuantity += trade.Quantity
-	
-	if buyOrder.FilledQuantity >= buyOrder.Quantity {
-		buyOrder.Status = "filled"
-	} else {
-		buyOrder.Status = "partial"
-	}
-	
-	if sellOrder.FilledQuantity >= sellOrder.Quantity {
-		sellOrder.Status = "filled"
-	} else {
-		sellOrder.Status = "partial"
-	}
-	
-	// 5. 거래 기록 저장
-	trade.Status = "completed"
-	app.trades[trade.ID] = &trade
-	
-	app.logger.Info("Trade executed successfully", 
-		"trade_id", trade.ID, 
-		"buyer", trade.BuyerID, 
-		"seller", trade.SellerID, 
-		"quantity", trade.Quantity, 
-		"price", trade.Price, 
-		"total_amount", trade.TotalAmount)
-	
-	return &types.ExecTxResult{Code: types.CodeTypeOK}
-}
-
 // handleDepositStablecoin는 스테이블코인 입금을 처리합니다.
 func (app *PoliticianApp) handleDepositStablecoin(txData *ptypes.TxData) *types.ExecTxResult {
 	app.logger.Info("Processing stablecoin deposit", "user_id", txData.UserID, "tx_id", txData.TxID)
 	
-	// 입금 데이터 파싱 (Politicians 필드에서 데이터 추출)
-	if len(txData.Politicians) < 3 {
-		return &types.ExecTxResult{Code: 1, Log: "입금 데이터가 부족합니다"}
-	}
-	
-	// amount:1000000, token_type:USDT, tx_hash:0x123, from_address:0x123 형태로 파싱
-	var amount int64
-	var tokenType, txHash, fromAddress string
-	
-	for _, data := range txData.Politicians {
-		if strings.HasPrefix(data, "amount:") {
-			if a, err := strconv.ParseInt(data[7:], 10, 64); err == nil {
-				amount = a
-			}
-		} else if strings.HasPrefix(data, "token_type:") {
-			tokenType = data[11:]
-		} else if strings.HasPrefix(data, "tx_hash:") {
-			txHash = data[8:]
-		} else if strings.HasPrefix(data, "from_address:") {
-			fromAddress = data[13:]
-		}
-	}
-	
-	if amount <= 0 || tokenType == "" || txHash == "" || fromAddress == "" {
-		return &types.ExecTxResult{Code: 2, Log: "입금 데이터 파싱 실패"}
-	}
-	
-	// 계정 확인
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		return &types.ExecTxResult{Code: 3, Log: "계정을 찾을 수 없습니다"}
-	}
+	deposit := txData.Msg.Deposit
+	amount, tokenType, txHash, fromAddress := deposit.Amount, deposit.Token, deposit.TxHash, deposit.FromAddress
+	account := app.accounts[txData.UserID]
 	
 	// 실제로는 블록체인에서 트랜잭션을 검증해야 함
 	// 여기서는 데모용으로 바로 처리
@@ -702,8 +500,6 @@ func (app *PoliticianApp) handleDepositStablecoin(txData *ptypes.TxData) *types.
 			"tx_hash", txHash,
 			"from_address", fromAddress,
 			"new_usdc_balance", account.USDCBalance)
-	} else {
-		return &types.ExecTxResult{Code: 3, Log: "지원하지 않는 토큰 타입"}
 	}
 	
 	return &types.ExecTxResult{Code: types.CodeTypeOK}
@@ -713,43 +509,12 @@ func (app *PoliticianApp) handleDepositStablecoin(txData *ptypes.TxData) *types.
 func (app *PoliticianApp) handleWithdrawStablecoin(txData *ptypes.TxData) *types.ExecTxResult {
 	app.logger.Info("Processing stablecoin withdrawal", "user_id", txData.UserID, "tx_id", txData.TxID)
 	
-	// 출금 데이터 파싱 (Politicians 필드에서 데이터 추출)
-	if len(txData.Politicians) < 2 {
-		return &types.ExecTxResult{Code: 1, Log: "출금 데이터가 부족합니다"}
-	}
-	
-	// amount:1000000, token_type:USDT, to_address:0x123 형태로 파싱
-	var amount int64
-	var tokenType, toAddress string
-	
-	for _, data := range txData.Politicians {
-		if strings.HasPrefix(data, "amount:") {
-			if a, err := strconv.ParseInt(data[7:], 10, 64); err == nil {
-				amount = a
-			}
-		} else if strings.HasPrefix(data, "token_type:") {
-			tokenType = data[11:]
-		} else if strings.HasPrefix(data, "to_address:") {
-			toAddress = data[11:]
-		}
-	}
-	
-	if amount <= 0 || tokenType == "" || toAddress == "" {
-		return &types.ExecTxResult{Code: 2, Log: "출금 데이터 파싱 실패"}
-	}
-	
-	// 계정 확인
-	account, exists := app.accounts[txData.UserID]
-	if !exists {
-		return &types.ExecTxResult{Code: 3, Log: "계정을 찾을 수 없습니다"}
-	}
+	withdrawal := txData.Msg.Withdraw
+	amount, tokenType, toAddress := withdrawal.Amount, withdrawal.Token, withdrawal.ToAddress
+	account := app.accounts[txData.UserID]
 	
-	// 토큰별 잔액 확인 및 차감
+	// 토큰별 잔액 차감 (사용 가능 잔액은 검증 단계에서 확인됨)
 	if tokenType == "USDT" {
-		availableBalance := account.USDTBalance - account.EscrowAccount.FrozenUSDTBalance
-		if availableBalance < amount {
-			return &types.ExecTxResult{Code: 4, Log: "사용 가능한 USDT 잔액이 부족합니다"}
-		}
 		account.USDTBalance -= amount
 		
 		app.logger.Info("USDT withdrawal successful", 
@@ -759,10 +524,6 @@ func (app *PoliticianApp) handleWithdrawStablecoin(txData *ptypes.TxData) *types
 			"new_usdt_balance", account.USDTBalance)
 			
 	} else if tokenType == "USDC" {
-		availableBalance := account.USDCBalance - account.EscrowAccount.FrozenUSDCBalance
-		if availableBalance < amount {
-			return &types.ExecTxResult{Code: 4, Log: "사용 가능한 USDC 잔액이 부족합니다"}
-		}
 		account.USDCBalance -= amount
 		
 		app.logger.Info("USDC withdrawal successful", 
@@ -770,8 +531,6 @@ func (app *PoliticianApp) handleWithdrawStablecoin(txData *ptypes.TxData) *types
 			"amount", amount, 
 			"to_address", toAddress,
 			"new_usdc_balance", account.USDCBalance)
-	} else {
-		return &types.ExecTxResult{Code: 3, Log: "지원하지 않는 토큰 타입"}
 	}
 	
 	// 실제로는 여기서 Polygon으로 토큰을 전송해야 함
@@ -793,15 +552,3 @@ func (app *PoliticianApp) ExtendVote(_ context.Context, req *types.RequestExtend
 func (app *PoliticianApp) VerifyVoteExtension(_ context.Context, req *types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error) {
 	return &types.ResponseVerifyVoteExtension{Status: types.ResponseVerifyVoteExtension_ACCEPT}, nil
 }
-func (app *PoliticianApp) ListSnapshots(_ context.Context, req *types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
-	return &types.ResponseListSnapshots{}, nil
-}
-func (app *PoliticianApp) OfferSnapshot(_ context.Context, req *types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
-	return &types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_ABORT}, nil
-}
-func (app *PoliticianApp) LoadSnapshotChunk(_ context.Context, req *types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
-	return &types.ResponseLoadSnapshotChunk{}, nil
-}
-func (app *PoliticianApp) ApplySnapshotChunk(_ context.Context, req *types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
-	return &types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ACCEPT}, nil
-}
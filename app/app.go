@@ -1,9 +1,13 @@
 package app
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/cometbft/cometbft/abci/types"
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/jclee286/politisian/pkg/smt"
 	ptypes "github.com/jclee286/politisian/pkg/types"
 )
 
@@ -12,14 +16,31 @@ type PoliticianApp struct {
 	types.BaseApplication
 	logger         log.Logger
 	db             dbm.DB
+	chainID        string                           // 트랜잭션 서명에 포함되는 체인 ID (SetChainID, InitChain)
 	height         int64
 	appHash        []byte
 	accounts       map[string]*ptypes.Account       // 사용자 계정 정보
 	proposals      map[string]*ptypes.Proposal      // 제안 정보
-	politicians    map[string]*ptypes.Politician    // 정치인 정보
+	politicians    map[string]*ptypes.Politician    // 정치인 정보 (키는 ptypes.PoliticianID)
+	registry       politicianRegistry               // 정치인 이름·지역 색인
 	orders         map[string]*ptypes.TradeOrder    // 거래 주문들
-	escrowAccounts map[string]*ptypes.EscrowAccount // 에스크로 계정들
+	orderBooks     orderBookIndex                   // 미체결 주문의 오더북별 색인
 	trades         map[string]*ptypes.Trade         // 체결된 거래들
+	orderSequence  int64                            // 마지막으로 부여한 주문 접수 순서
+	govParams      ptypes.GovParams                 // 정치인 등록 제안의 거버넌스 파라미터
+	authParams     ptypes.AuthParams                // 계정 인증 파라미터
+
+	tree          *smt.Tree         // 상태 키-값 쌍의 머클 트리 (루트 = 앱 해시)
+	dirty         map[string]bool   // 이번 블록에서 바뀐 상태 키
+	pendingWrites map[string][]byte // 트리에 반영되었지만 아직 저장되지 않은 값 (nil은 삭제)
+	committedTree *smt.Tree         // 마지막 커밋 시점의 트리 (쿼리 증명용)
+
+	exec execContext // 실행 중인 블록과 트랜잭션
+
+	snapshotOpts SnapshotOptions  // 상태 동기화 스냅샷 설정
+	snapshotting atomic.Bool      // 백그라운드에서 스냅샷을 만드는 중인지
+	snapshotWG   sync.WaitGroup   // 백그라운드 스냅샷 고루틴
+	restore      *snapshotRestore // 진행 중인 스냅샷 복원
 }
 
 func NewPoliticianApp(db dbm.DB, logger log.Logger) *PoliticianApp {
@@ -30,139 +51,21 @@ func NewPoliticianApp(db dbm.DB, logger log.Logger) *PoliticianApp {
 		proposals:      make(map[string]*ptypes.Proposal),
 		politicians:    make(map[string]*ptypes.Politician),
 		orders:         make(map[string]*ptypes.TradeOrder),
-		escrowAccounts: make(map[string]*ptypes.EscrowAccount),
 		trades:         make(map[string]*ptypes.Trade),
+		tree:           smt.New(),
+		dirty:          make(map[string]bool),
+		pendingWrites:  make(map[string][]byte),
 	}
 	// DB에서 마지막 상태를 불러옵니다.
 	if err := app.loadState(); err != nil {
 		// 로드 실패 시 애플리케이션을 중단해야 합니다.
 		panic("Failed to load state: " + err.Error())
 	}
-	
-	// 가상의 정치인 데이터 초기화 (앱 시작 시 한번만)
-	app.initializeDefaultPoliticians()
-	
 	return app
 }
 
-// initializeDefaultPoliticians는 가상의 정치인 데이터를 초기화합니다.
-func (app *PoliticianApp) initializeDefaultPoliticians() {
-	// 이미 정치인이 있다면 초기화하지 않음 (중복 방지)
-	if len(app.politicians) > 0 {
-		app.logger.Info("Politicians already exist, skipping initialization", "count", len(app.politicians))
-		return
-	}
-
-	// 실제 21대 국회의원 데이터 (주요 정치인들 샘플)
-	defaultPoliticians := []*ptypes.Politician{
-		{
-			Name:         "이재명",
-			Region:       "경기 계양구 갑",
-			Party:        "더불어민주당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "한동훈",
-			Region:       "비례대표",
-			Party:        "국민의힘",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "조국",
-			Region:       "서울 종로구",
-			Party:        "조국혁신당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "안철수",
-			Region:       "서울 관악구 을",
-			Party:        "국민의당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "심상정",
-			Region:       "비례대표",
-			Party:        "정의당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "이낙연",
-			Region:       "서울 종로구",
-			Party:        "더불어민주당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "김기현",
-			Region:       "울산 남구 을",
-			Party:        "국민의힘",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "박홍근",
-			Region:       "서울 중구·성동구 갑",
-			Party:        "더불어민주당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "추경호",
-			Region:       "대구 수성구 갑",
-			Party:        "국민의힘",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-		{
-			Name:         "우상호",
-			Region:       "서울 양천구 을",
-			Party:        "더불어민주당",
-			IntroUrl:     "",
-			Supporters:   []string{},
-			TotalCoinSupply:  10_000_000,  // 1,000만개
-			RemainingCoins:   10_000_000,  // 초기에는 모두 남아있음
-			DistributedCoins: 0,           // 아직 배포 안됨
-		},
-	}
-
-	// 정치인들을 맵에 저장
-	for _, politician := range defaultPoliticians {
-		politicianID := politician.Name + "-" + politician.Region
-		app.politicians[politicianID] = politician
-		app.logger.Info("Initialized default politician", "name", politician.Name, "region", politician.Region, "party", politician.Party)
-	}
-
-	app.logger.Info("Default politicians initialization completed", "total_count", len(app.politicians))
+// SetChainID는 트랜잭션 서명을 확인할 때 쓰는 체인 ID를 정합니다. 노드를 시작하기 전에 제네시스의 체인 ID로 호출해야 합니다.
+// 재시작하거나 스냅샷으로 시작한 노드는 InitChain을 받지 않으므로 이 값이 없으면 모든 서명이 거부됩니다.
+func (app *PoliticianApp) SetChainID(chainID string) {
+	app.chainID = chainID
 }
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"bytes"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// verifySignature는 봉투의 서명이 봉투에 담긴 공개키로 chainID 체인에 대해 만들어졌는지 확인합니다.
+// 상태를 보지 않는 검사이므로 디코딩 단계에서 수행합니다.
+func verifySignature(signed *ptypes.SignedTx, chainID string) *txError {
+	if len(signed.PubKey) != ed25519.PubKeySize {
+		return newTxError(TxCodeBadSignature, "잘못된 공개키입니다")
+	}
+	if !ed25519.PubKey(signed.PubKey).VerifySignature(signed.SignBytes(chainID), signed.Signature) {
+		return newTxError(TxCodeBadSignature, "서명이 올바르지 않습니다")
+	}
+	return nil
+}
+
+// bindKeyAction은 공개키가 등록되기 전에 만들어진 계정에 키를 등록하는 액션입니다.
+const bindKeyAction = "bind_account_key"
+
+// authenticateTx는 서명자가 TxData.UserID 계정에 대해 이 액션을 서명할 수 있는지와 nonce가 증가했는지 확인합니다.
+// 계정 생성, 입금 기록, 이전 계정의 키 등록(ptypes.IsRegistrarAction)은 등록 키(AuthParams.KeyRegistrar)만 서명할 수 있고,
+// 그 밖의 액션은 이미 있는 계정에 등록된 공개키가 서명해야 합니다.
+// 새 계정의 공개키는 서명자가 아니라 CreateAccountMsg에서 오므로, 아직 없는 ID를 먼저 서명해 차지할 수 없습니다.
+func (app *PoliticianApp) authenticateTx(signed *ptypes.SignedTx, txData *ptypes.TxData) *txError {
+	account, exists := app.accounts[txData.UserID]
+	var signer []byte
+	var lastNonce uint64
+	if exists {
+		signer, lastNonce = account.PubKey, account.Nonce
+	}
+	if ptypes.IsRegistrarAction(txData.Action) {
+		signer = app.authParams.KeyRegistrar
+		if len(signer) == 0 {
+			return newTxError(TxCodeUnauthorized, "키 등록자가 설정되어 있지 않습니다")
+		}
+	} else if !exists {
+		return newTxError(TxCodeUnauthorized, "존재하지 않는 계정입니다")
+	} else if len(signer) == 0 {
+		return newTxError(TxCodeUnauthorized, "공개키가 없는 계정입니다. 먼저 bind_account_key로 공개키를 등록해야 합니다")
+	}
+	if !bytes.Equal(signer, signed.PubKey) {
+		return newTxError(TxCodeUnauthorized, "이 액션에 서명할 수 있는 공개키가 아닙니다")
+	}
+	if signed.Nonce <= lastNonce {
+		return newTxError(TxCodeBadNonce, "nonce는 마지막으로 사용한 nonce보다 커야 합니다")
+	}
+	return nil
+}
+
+// consumeNonce는 인증된 트랜잭션의 nonce를 계정에 기록합니다. create_profile이 실패해 계정이 없으면 기록하지 않습니다.
+// 실행이 실패한 트랜잭션도 nonce를 소모하여 같은 트랜잭션이 다시 재생되지 않게 합니다.
+func (app *PoliticianApp) consumeNonce(signed *ptypes.SignedTx, txData *ptypes.TxData) {
+	if account, exists := app.accounts[txData.UserID]; exists {
+		account.Nonce = signed.Nonce
+	}
+}
+
+func (app *PoliticianApp) validateBindAccountKey(txData *ptypes.TxData) *txError {
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeAccountNotFound, "계정을 찾을 수 없습니다")
+	}
+	if len(account.PubKey) > 0 {
+		return newTxError(TxCodeKeyAlreadyBound, "이미 공개키가 등록된 계정입니다")
+	}
+	return nil
+}
+
+// handleBindAccountKey는 공개키가 없는 이전 계정에 등록 키가 확인한 공개키를 등록합니다.
+func (app *PoliticianApp) handleBindAccountKey(txData *ptypes.TxData) *types.ExecTxResult {
+	account := app.accounts[txData.UserID]
+	account.PubKey = append([]byte(nil), txData.Msg.BindKey.PubKey...)
+	app.touchAccount(txData.UserID)
+	app.logger.Info("Bound account key", "user_id", txData.UserID)
+	return &types.ExecTxResult{Code: types.CodeTypeOK}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// signWith는 key로 테스트 체인의 nonce와 함께 서명한 트랜잭션 바이트를 만듭니다.
+func signWith(t *testing.T, key ed25519.PrivKey, nonce uint64, tx ptypes.TxData) []byte {
+	t.Helper()
+	signed, err := ptypes.SignTx(key, testChainID, nonce, &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ptypes.MarshalSignedTx(signed)
+}
+
+func createProfile(userID string, pubKey []byte) ptypes.TxData {
+	return ptypes.TxData{Action: "create_profile", UserID: userID, Msg: &ptypes.TxMsg{CreateAccount: &ptypes.CreateAccountMsg{PubKey: pubKey}}}
+}
+
+func deposit(userID string, amount int64) ptypes.TxData {
+	return ptypes.TxData{Action: "deposit_stablecoin", UserID: userID, Msg: &ptypes.TxMsg{Deposit: &ptypes.DepositMsg{Token: "USDT", Amount: amount, TxHash: "0xabc", FromAddress: "0xdef"}}}
+}
+
+// 계정은 등록 키가 서명한 create_profile로만 만들어지고, 계정에는 서명자가 아니라 메시지의 공개키가 등록됩니다.
+func TestCreateProfileRequiresRegistrar(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{})
+	aliceKey := testUserKey("alice")
+	attacker := ed25519.GenPrivKeyFromSecret([]byte("attacker"))
+
+	results := chain.execRaw(
+		signWith(t, aliceKey, 1, createProfile("alice", aliceKey.PubKey().Bytes())),
+		signWith(t, attacker, 1, createProfile("alice", attacker.PubKey().Bytes())),
+	)
+	for i, r := range results {
+		if r.Code != 13 {
+			t.Errorf("self-signed create_profile %d: code %d (%s), want 13", i, r.Code, r.Log)
+		}
+	}
+	if _, exists := chain.app.accounts["alice"]; exists {
+		t.Fatal("create_profile not signed by the registrar created an account")
+	}
+
+	// 메시지의 공개키가 빠진 create_profile은 서명과 관계없이 거부됩니다.
+	if r := chain.execRaw(signWith(t, testRegistrarKey, 1, ptypes.TxData{Action: "create_profile", UserID: "alice"}))[0]; r.Code != 15 {
+		t.Fatalf("create_profile without a key: code %d (%s), want 15", r.Code, r.Log)
+	}
+
+	chain.block(createProfile("alice", aliceKey.PubKey().Bytes()))
+	account := chain.app.accounts["alice"]
+	if !bytes.Equal(account.PubKey, aliceKey.PubKey().Bytes()) {
+		t.Fatalf("account key = %X, want the key from the message", account.PubKey)
+	}
+	// 새 계정은 메시지로 등록한 키로 서명하고, 등록 키로는 사용자 액션에 서명할 수 없습니다.
+	supporters := ptypes.TxData{Action: "update_supporters", UserID: "alice"}
+	if r := chain.execRaw(signWith(t, testRegistrarKey, 10, supporters))[0]; r.Code != 13 {
+		t.Fatalf("user action signed by the registrar: code %d (%s), want 13", r.Code, r.Log)
+	}
+	chain.block(supporters)
+}
+
+// 입금 기록은 등록 키만 서명할 수 있습니다.
+func TestDepositRequiresRegistrar(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{Accounts: map[string]*ptypes.Account{"alice": {Address: "alice"}}})
+	if r := chain.execRaw(signWith(t, testUserKey("alice"), 1, deposit("alice", 1_000)))[0]; r.Code != 13 {
+		t.Fatalf("self-service deposit: code %d (%s), want 13", r.Code, r.Log)
+	}
+	if balance := chain.app.accounts["alice"].USDTBalance; balance != 0 {
+		t.Fatalf("balance after rejected deposit = %d, want 0", balance)
+	}
+	chain.block(deposit("alice", 1_000))
+	if balance := chain.app.accounts["alice"].USDTBalance; balance != 1_000 {
+		t.Fatalf("balance after registrar deposit = %d, want 1000", balance)
+	}
+}
+
+// 등록 키가 없는 체인에서는 계정을 만들 수 없습니다.
+func TestCreateProfileWithoutRegistrar(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{AuthParams: &ptypes.AuthParams{}})
+	if r := chain.exec(createProfile("alice", testUserKey("alice").PubKey().Bytes()))[0]; r.Code != 13 {
+		t.Fatalf("create_profile without a registrar: code %d (%s), want 13", r.Code, r.Log)
+	}
+}
+
+// 서명이 틀리거나 다른 체인 ID로 서명한 트랜잭션은 CheckTx와 FinalizeBlock 모두에서 거부됩니다.
+func TestRejectsBadSignatures(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{Accounts: map[string]*ptypes.Account{"alice": {Address: "alice"}}})
+	tx := ptypes.TxData{Action: "update_supporters", UserID: "alice"}
+	key := testUserKey("alice")
+
+	tampered, err := ptypes.UnmarshalSignedTx(signWith(t, key, 1, tx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered.Signature[0] ^= 0xff
+	otherChain, err := ptypes.SignTx(key, "other-chain", 1, &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortKey, err := ptypes.UnmarshalSignedTx(signWith(t, key, 1, tx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortKey.PubKey = shortKey.PubKey[:16]
+
+	for name, raw := range map[string][]byte{
+		"tampered signature": ptypes.MarshalSignedTx(tampered),
+		"other chain ID":     ptypes.MarshalSignedTx(otherChain),
+		"short public key":   ptypes.MarshalSignedTx(shortKey),
+	} {
+		check, err := chain.app.CheckTx(context.Background(), &types.RequestCheckTx{Tx: raw})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if check.Code != 12 {
+			t.Errorf("%s: CheckTx code %d (%s), want 12", name, check.Code, check.Log)
+		}
+		if r := chain.execRaw(raw)[0]; r.Code != 12 {
+			t.Errorf("%s: FinalizeBlock code %d (%s), want 12", name, r.Code, r.Log)
+		}
+	}
+	if nonce := chain.app.accounts["alice"].Nonce; nonce != 0 {
+		t.Fatalf("rejected signatures consumed nonce %d", nonce)
+	}
+}
+
+// 한 번 사용한 nonce나 그보다 작은 nonce는 다시 쓸 수 없고, 실행이 실패한 트랜잭션도 nonce를 소모합니다.
+func TestNonceReplay(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{Accounts: map[string]*ptypes.Account{"alice": {Address: "alice"}}})
+	key := testUserKey("alice")
+	tx := ptypes.TxData{Action: "update_supporters", UserID: "alice"}
+
+	if r := chain.execRaw(signWith(t, key, 0, tx))[0]; r.Code != 14 {
+		t.Fatalf("nonce 0: code %d (%s), want 14", r.Code, r.Log)
+	}
+	first := signWith(t, key, 5, tx)
+	if r := chain.execRaw(first)[0]; r.Code != types.CodeTypeOK {
+		t.Fatalf("nonce 5: code %d (%s)", r.Code, r.Log)
+	}
+	// 같은 트랜잭션을 다시 제출하거나, 더 작은 nonce를 쓰면 거부됩니다.
+	for name, raw := range map[string][]byte{"replay": first, "lower nonce": signWith(t, key, 4, tx)} {
+		if r := chain.execRaw(raw)[0]; r.Code != 14 {
+			t.Errorf("%s: code %d (%s), want 14", name, r.Code, r.Log)
+		}
+	}
+	// 같은 블록 안에서도 두 번째 사용은 거부됩니다.
+	again := signWith(t, key, 6, tx)
+	results := chain.execRaw(again, again)
+	if results[0].Code != types.CodeTypeOK || results[1].Code != 14 {
+		t.Fatalf("same nonce twice in a block: codes %d, %d, want 0, 14", results[0].Code, results[1].Code)
+	}
+	// 실행에 실패한 트랜잭션(없는 주문 취소)도 nonce를 소모합니다.
+	cancel := ptypes.TxData{Action: "cancel_order", UserID: "alice", Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: "missing"}}}
+	if r := chain.execRaw(signWith(t, key, 7, cancel))[0]; r.Code == types.CodeTypeOK {
+		t.Fatal("cancelling a missing order succeeded")
+	}
+	if nonce := chain.app.accounts["alice"].Nonce; nonce != 7 {
+		t.Fatalf("nonce after failed tx = %d, want 7", nonce)
+	}
+}
+
+// 공개키가 없는 이전 계정에는 등록 키가 서명한 bind_account_key로만 키를 등록할 수 있고, 등록한 뒤에는 바꿀 수 없습니다.
+func TestBindAccountKey(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{Accounts: map[string]*ptypes.Account{"legacy": {Address: "legacy"}}})
+	chain.app.accounts["legacy"].PubKey = nil
+	userKey := testUserKey("legacy")
+	attacker := ed25519.GenPrivKeyFromSecret([]byte("attacker"))
+	bind := func(pubKey []byte) ptypes.TxData {
+		return ptypes.TxData{Action: bindKeyAction, UserID: "legacy", Msg: &ptypes.TxMsg{BindKey: &ptypes.BindKeyMsg{PubKey: pubKey}}}
+	}
+	supporters := ptypes.TxData{Action: "update_supporters", UserID: "legacy"}
+
+	// 키가 없는 계정은 어떤 키로도 일반 트랜잭션에 서명할 수 없고, 스스로 키를 등록할 수도 없습니다.
+	for name, raw := range map[string][]byte{
+		"first signer":     signWith(t, attacker, 1, supporters),
+		"self-signed bind": signWith(t, attacker, 1, bind(attacker.PubKey().Bytes())),
+	} {
+		if r := chain.execRaw(raw)[0]; r.Code != 13 {
+			t.Errorf("%s: code %d (%s), want 13", name, r.Code, r.Log)
+		}
+	}
+	if len(chain.app.accounts["legacy"].PubKey) != 0 {
+		t.Fatal("a key was bound without the registrar")
+	}
+
+	chain.block(bind(userKey.PubKey().Bytes()))
+	if !bytes.Equal(chain.app.accounts["legacy"].PubKey, userKey.PubKey().Bytes()) {
+		t.Fatal("bind_account_key did not bind the key from the message")
+	}
+	chain.block(supporters)
+	// 키가 있는 계정에는 등록 키도 다른 키를 등록할 수 없습니다.
+	if r := chain.exec(bind(attacker.PubKey().Bytes()))[0]; r.Code == types.CodeTypeOK {
+		t.Fatal("bind_account_key replaced an existing key")
+	}
+	if !bytes.Equal(chain.app.accounts["legacy"].PubKey, userKey.PubKey().Bytes()) {
+		t.Fatal("account key changed after a rejected bind")
+	}
+}
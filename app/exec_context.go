@@ -0,0 +1,36 @@
+package app
+
+import (
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// execContext는 FinalizeBlock에서 실행 중인 블록과 트랜잭션의 정보입니다. CheckTx에서는 다음 블록의 높이와 마지막 블록의 시간을 담습니다.
+// 상태 머신 안에서 만드는 ID와 시간은 모두 여기서 가져와야 검증자마다 같은 결과가 나옵니다.
+// uuid나 time.Now()처럼 노드마다 달라지는 값은 사용하지 않습니다.
+type execContext struct {
+	height  int64  // 블록 높이
+	time    int64  // 블록 헤더 시간 (Unix 초)
+	txIndex int    // 블록 안에서 트랜잭션의 위치
+	txHash  []byte // 트랜잭션 해시
+	idCount map[string]int
+}
+
+// beginTx는 블록의 i번째 트랜잭션 실행을 시작합니다.
+func (c *execContext) beginTx(i int, tx []byte) {
+	c.txIndex = i
+	c.txHash = cmttypes.Tx(tx).Hash()
+	c.idCount = make(map[string]int)
+}
+
+// newID는 현재 트랜잭션이 만드는 kind 종류 엔티티의 ID를 블록 높이, 트랜잭션 위치와 해시로부터 만듭니다.
+// 한 트랜잭션이 같은 종류의 엔티티를 여러 개 만들면(예: 여러 건의 체결) 두 번째부터 순번을 붙입니다.
+func (c *execContext) newID(kind string) string {
+	id := fmt.Sprintf("%s_%d_%d_%X", kind, c.height, c.txIndex, c.txHash[:4])
+	if n := c.idCount[kind]; n > 0 {
+		id += fmt.Sprintf("_%d", n)
+	}
+	c.idCount[kind]++
+	return id
+}
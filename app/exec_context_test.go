@@ -0,0 +1,88 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+func TestNewID(t *testing.T) {
+	tx := []byte("tx")
+	ids := func(height int64, index int, tx []byte, kinds ...string) []string {
+		c := execContext{height: height}
+		c.beginTx(index, tx)
+		out := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			out = append(out, c.newID(kind))
+		}
+		return out
+	}
+
+	// 같은 블록 높이, 위치, 트랜잭션이면 다시 실행해도 같은 ID가 같은 순서로 나옵니다.
+	first := ids(5, 1, tx, "trade", "trade", "order", "trade")
+	if replay := ids(5, 1, tx, "trade", "trade", "order", "trade"); !reflect.DeepEqual(first, replay) {
+		t.Fatalf("replayed IDs %v, want %v", replay, first)
+	}
+	// 같은 종류의 두 번째 ID부터 순번이 붙고, 순번은 종류마다 따로 셉니다.
+	seen := make(map[string]bool)
+	for _, id := range first {
+		if seen[id] {
+			t.Fatalf("duplicate ID %s in %v", id, first)
+		}
+		seen[id] = true
+	}
+	if first[0]+"_1" != first[1] || first[0]+"_2" != first[3] {
+		t.Fatalf("IDs %v, want numbered suffixes on repeated trades", first)
+	}
+
+	// 위치, 높이, 트랜잭션 중 하나라도 다르면 다른 ID가 됩니다.
+	for name, other := range map[string][]string{
+		"same tx at another index":  ids(5, 2, tx, "trade"),
+		"same tx at another height": ids(6, 1, tx, "trade"),
+		"another tx":                ids(5, 1, []byte("other"), "trade"),
+	} {
+		if other[0] == first[0] {
+			t.Errorf("%s: ID %s collides with the original", name, other[0])
+		}
+	}
+
+	// 다음 트랜잭션을 시작하면 순번이 처음부터 다시 셉니다.
+	c := execContext{height: 5}
+	c.beginTx(1, tx)
+	c.newID("trade")
+	c.beginTx(1, tx)
+	if id := c.newID("trade"); id != first[0] {
+		t.Fatalf("ID after beginTx = %s, want %s", id, first[0])
+	}
+}
+
+// 같은 상태에서 같은 블록을 실행한 두 노드는 주문과 체결의 ID와 시간이 모두 같습니다.
+func TestBlockReplayIsDeterministic(t *testing.T) {
+	a, id := orderTypeChain(t)
+	b, _ := orderTypeChain(t)
+	raw := [][]byte{
+		a.sign(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 4, Price: 120})),
+		a.sign(placeOrder("other", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 2, Price: 130})),
+	}
+	resultsA, resultsB := a.execRaw(raw...), b.execRaw(raw...)
+	for i := range raw {
+		if string(resultsA[i].Data) != string(resultsB[i].Data) || resultsA[i].Code != 0 {
+			t.Fatalf("tx %d: results %q (code %d) and %q differ", i, resultsA[i].Data, resultsA[i].Code, resultsB[i].Data)
+		}
+	}
+	if !reflect.DeepEqual(a.app.orders, b.app.orders) || !reflect.DeepEqual(a.app.trades, b.app.trades) {
+		t.Fatal("orders or trades differ between replays")
+	}
+	if len(a.app.trades) != 2 {
+		t.Fatalf("%d trades, want 2", len(a.app.trades))
+	}
+	for tradeID, trade := range a.app.trades {
+		if trade.Timestamp != blockTime(a.height) {
+			t.Errorf("trade %s timestamp %d, want the block time %d", tradeID, trade.Timestamp, blockTime(a.height))
+		}
+	}
+	if string(a.app.appHash) != string(b.app.appHash) {
+		t.Fatalf("app hashes differ: %X and %X", a.app.appHash, b.app.appHash)
+	}
+}
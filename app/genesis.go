@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// initGenesis는 검증된 제네시스 상태를 메모리에 불러옵니다.
+// 정치인 목록은 제네시스의 로스터(init --roster)로만 정해지며, 키는 정치인 ID입니다.
+func (app *PoliticianApp) initGenesis(gs *ptypes.GenesisState) error {
+	if err := gs.Validate(); err != nil {
+		return fmt.Errorf("invalid genesis state: %w", err)
+	}
+
+	if gs.Politicians != nil {
+		app.politicians = gs.Politicians
+	}
+	if gs.Accounts != nil {
+		app.accounts = gs.Accounts
+	}
+	if gs.Proposals != nil {
+		app.proposals = gs.Proposals
+	}
+	for i := range gs.Orders {
+		order := gs.Orders[i]
+		// 주문 종류와 유효 기간이 도입되기 전의 주문은 모두 지정가 GTC 주문입니다.
+		order.Kind, order.TimeInForce = ptypes.NormalizeOrderOptions(order.Kind, order.TimeInForce)
+		app.orders[order.ID] = &order
+	}
+	for i := range gs.Trades {
+		trade := gs.Trades[i]
+		// 결제 통화가 기록되기 전의 거래는 모두 테더코인으로 결제되었습니다.
+		if trade.Currency == "" {
+			trade.Currency = "USDT"
+		}
+		app.trades[trade.ID] = &trade
+	}
+	app.orderSequence = gs.OrderSequence
+	app.govParams = ptypes.DefaultGovParams()
+	if gs.GovParams != nil {
+		app.govParams = *gs.GovParams
+	}
+	if gs.AuthParams != nil {
+		app.authParams = *gs.AuthParams
+	}
+
+	// JSON에서 생략된 맵은 nil이 되므로 핸들러가 바로 쓸 수 있도록 채웁니다.
+	for _, account := range app.accounts {
+		if account.PoliticianCoins == nil {
+			account.PoliticianCoins = make(map[string]int64)
+		}
+		if account.ReceivedCoins == nil {
+			account.ReceivedCoins = make(map[string]bool)
+		}
+		ensureEscrowAccount(account)
+	}
+	for _, politician := range app.politicians {
+		if politician.Supporters == nil {
+			politician.Supporters = []string{}
+		}
+	}
+	for _, proposal := range app.proposals {
+		if proposal.Votes == nil {
+			proposal.Votes = make(map[string]bool)
+		}
+		// 투표 기간이 없는 제안은 체인의 첫 블록부터 투표 기간을 시작합니다.
+		if proposal.VotingEndHeight == 0 {
+			proposal.SubmitHeight = app.height + 1
+			proposal.VotingEndHeight = proposal.SubmitHeight + app.govParams.VotingPeriod
+		}
+		if proposal.TallyMode == "" {
+			proposal.TallyMode = ptypes.TallyModeOneAccountOneVote
+		}
+		if proposal.Status == "" {
+			proposal.Status = ptypes.ProposalStatusVoting
+		}
+		if proposal.Type == "" {
+			proposal.Type = ptypes.ProposalTypeRegister
+		}
+	}
+
+	// 사용자 정보 중 비밀번호 해시와 PIN은 서버에만 보관하고, 체인에는 계정만 만듭니다.
+	for id, user := range gs.Users {
+		if _, exists := app.accounts[id]; !exists {
+			app.accounts[id] = newGenesisAccount(id, user.Email)
+		}
+	}
+
+	app.reindexPoliticians()
+	app.reindexOrders()
+
+	app.logger.Info("Loaded genesis state",
+		"accounts", len(app.accounts),
+		"politicians", len(app.politicians),
+		"proposals", len(app.proposals),
+		"orders", len(app.orders),
+		"trades", len(app.trades))
+	return nil
+}
+
+// newGenesisAccount는 제네시스 사용자에게 handleCreateProfile과 같은 빈 계정을 만듭니다.
+func newGenesisAccount(id, email string) *ptypes.Account {
+	return &ptypes.Account{
+		Address:         id,
+		Email:           email,
+		Politicians:     []string{},
+		PoliticianCoins: make(map[string]int64),
+		ReceivedCoins:   make(map[string]bool),
+		ActiveOrders:    []ptypes.TradeOrder{},
+		EscrowAccount: ptypes.EscrowAccount{
+			UserID:                id,
+			FrozenPoliticianCoins: make(map[string]int64),
+			ActiveOrders:          []string{},
+		},
+	}
+}
+
+// ExportGenesis는 height 시점의 상태를 제네시스 상태로 내보내고, 실제로 내보낸 높이를 반환합니다.
+// height가 0이거나 마지막 커밋 높이이면 현재 상태를, 그보다 이전이면 그 높이의 스냅샷을 사용합니다.
+func (app *PoliticianApp) ExportGenesis(height int64) (*ptypes.GenesisState, int64, error) {
+	if height == 0 || height == app.height {
+		state := &ptypes.AppState{
+			Accounts:      app.accounts,
+			Proposals:     app.proposals,
+			Politicians:   app.politicians,
+			Orders:        app.orders,
+			Trades:        app.trades,
+			OrderSequence: app.orderSequence,
+			GovParams:     &app.govParams,
+			AuthParams:    &app.authParams,
+		}
+		return genesisFromState(state), app.height, nil
+	}
+	if height > app.height {
+		return nil, 0, fmt.Errorf("height %d is above the last committed height %d", height, app.height)
+	}
+	state, err := app.snapshotState(uint64(height))
+	if err != nil {
+		return nil, 0, fmt.Errorf("no usable snapshot at height %d: %w", height, err)
+	}
+	return genesisFromState(state), height, nil
+}
+
+// genesisFromState는 상태를 제네시스 형식으로 바꿉니다. 주문은 접수 순서, 거래는 시간 순으로 정렬합니다.
+func genesisFromState(state *ptypes.AppState) *ptypes.GenesisState {
+	gs := &ptypes.GenesisState{
+		Accounts:      state.Accounts,
+		Politicians:   state.Politicians,
+		Proposals:     state.Proposals,
+		Orders:        make([]ptypes.TradeOrder, 0, len(state.Orders)),
+		Trades:        make([]ptypes.Trade, 0, len(state.Trades)),
+		OrderSequence: state.OrderSequence,
+		GovParams:     state.GovParams,
+		AuthParams:    state.AuthParams,
+	}
+	for _, order := range state.Orders {
+		gs.Orders = append(gs.Orders, *order)
+	}
+	sort.Slice(gs.Orders, func(i, j int) bool { return gs.Orders[i].Sequence < gs.Orders[j].Sequence })
+	for _, trade := range state.Trades {
+		gs.Trades = append(gs.Trades, *trade)
+	}
+	sort.Slice(gs.Trades, func(i, j int) bool {
+		if gs.Trades[i].Timestamp != gs.Trades[j].Timestamp {
+			return gs.Trades[i].Timestamp < gs.Trades[j].Timestamp
+		}
+		return gs.Trades[i].ID < gs.Trades[j].ID
+	})
+	return gs
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 접수, 가격 개선이 있는 부분 체결, 취소 뒤에 내보낸 제네시스는 동결 잔액과 미체결 주문이 맞아 검증을 통과합니다.
+func TestExportedGenesisKeepsEscrowInvariant(t *testing.T) {
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	politician.RemainingCoins -= 100
+	politician.DistributedCoins = 100
+	id := politician.ID
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"buyer":  {Address: "buyer", USDTBalance: 10_000},
+			"seller": {Address: "seller", PoliticianCoins: map[string]int64{string(id): 100}},
+		},
+	})
+
+	results := chain.block(
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 3, Price: 90}),
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 5, Price: 150}),
+	)
+	// 매수 주문은 90에 3개 체결되고 7개가 100에 남습니다. 체결 가격과의 차이만큼 동결이 줄어야 합니다.
+	buy := string(chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 10, Price: 100}))[0].Data)
+	chain.block(ptypes.TxData{Action: "cancel_order", UserID: "seller", Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: string(results[1].Data)}}})
+	chain.block(placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 4, Price: 200}))
+
+	if got := chain.app.accounts["buyer"].EscrowAccount.FrozenUSDTBalance; got != 700 {
+		t.Fatalf("buyer frozen USDT = %d, want 700 for order %s", got, buy)
+	}
+	gs, _, err := chain.app.ExportGenesis(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.Validate(); err != nil {
+		t.Fatalf("exported genesis does not validate: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package app
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/cometbft/cometbft/abci/types"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// proposalTally는 제안 하나의 집계 결과입니다.
+type proposalTally struct {
+	Mode          ptypes.TallyMode
+	Voters        int64 // 투표한 계정 수
+	Yes           int64 // 찬성 계정 수
+	No            int64 // 반대 계정 수
+	YesWeight     int64 // 집계 방식에 따른 찬성 표
+	NoWeight      int64 // 집계 방식에 따른 반대 표
+	QuorumReached bool  // 투표 계정 수가 정족수 이상인지
+	Passed        bool  // 정족수를 채우고 찬성 표 비율이 기준을 넘었는지
+}
+
+// tallyProposal은 제안의 투표를 제안에 기록된 집계 방식과 거버넌스 파라미터로 집계합니다.
+// holdings는 투표자가 보유한 정치인 코인 전체 수량을 반환하며, 계정당 한 표 방식에서는 쓰지 않습니다.
+// 정족수는 집계 방식과 관계없이 투표한 계정 수로 판단합니다.
+func tallyProposal(proposal *ptypes.Proposal, params ptypes.GovParams, holdings func(voter string) int64) proposalTally {
+	t := proposalTally{Mode: proposal.TallyMode, Voters: int64(len(proposal.Votes))}
+	if t.Mode == "" {
+		t.Mode = ptypes.TallyModeOneAccountOneVote
+	}
+	for voter, yes := range proposal.Votes {
+		weight := voteWeight(t.Mode, holdings, voter)
+		if yes {
+			t.Yes++
+			t.YesWeight += weight
+		} else {
+			t.No++
+			t.NoWeight += weight
+		}
+	}
+	t.QuorumReached = t.Voters >= params.Quorum
+	t.Passed = t.QuorumReached && t.YesWeight > 0 && t.YesWeight*100 > params.ApprovalThreshold*(t.YesWeight+t.NoWeight)
+	return t
+}
+
+// voteWeight는 집계 방식에 따른 투표자 한 명의 표 수입니다. 코인이 없는 계정은 가중 방식에서 0표입니다.
+func voteWeight(mode ptypes.TallyMode, holdings func(voter string) int64, voter string) int64 {
+	switch mode {
+	case ptypes.TallyModeCoinWeighted:
+		return holdings(voter)
+	case ptypes.TallyModeQuadratic:
+		return isqrt(holdings(voter))
+	default:
+		return 1
+	}
+}
+
+// isqrt는 n의 제곱근을 내림한 정수입니다. 부동소수점 결과에 기대지 않도록 보정해 모든 노드에서 같은 값을 얻습니다.
+// 제곱 대신 나눗셈으로 비교하므로 n이 int64 최댓값에 가까워도 넘치지 않습니다.
+func isqrt(n int64) int64 {
+	if n <= 0 {
+		return 0
+	}
+	r := int64(math.Sqrt(float64(n)))
+	for r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// coinHoldings는 계정이 보유한 정치인 코인 전체 수량입니다. 매도 주문에 묶인 코인도 보유량에 포함합니다.
+func (app *PoliticianApp) coinHoldings(userID string) int64 {
+	account, exists := app.accounts[userID]
+	if !exists {
+		return 0
+	}
+	var total int64
+	for _, amount := range account.PoliticianCoins {
+		total += amount
+	}
+	return total
+}
+
+// accountAge는 계정이 만들어진 뒤 지난 블록 수를 실행 중인 블록(CheckTx에서는 다음 블록) 기준으로 반환합니다.
+// 높이를 기록하기 전에 만들어진 계정과 제네시스 계정은 높이 0에서 만들어진 것으로 봅니다.
+func (app *PoliticianApp) accountAge(account *ptypes.Account) int64 {
+	return app.exec.height - account.CreatedHeight
+}
+
+// openProposalFor는 같은 정치인 ID이거나 이름, 지역, 정당을 정규화한 값이 같은 투표 중인 제안을 찾습니다.
+func (app *PoliticianApp) openProposalFor(id ptypes.PoliticianID, name, region, party string) (*ptypes.Proposal, bool) {
+	key := ptypes.PoliticianDedupKey(name, region, party)
+	for _, proposal := range app.proposals {
+		if proposal.Status != ptypes.ProposalStatusVoting {
+			continue
+		}
+		p := &proposal.Politician
+		if p.ID == id || ptypes.PoliticianDedupKey(p.Name, p.Region, p.Party) == key {
+			return proposal, true
+		}
+	}
+	return nil, false
+}
+
+// tallyEndedProposals는 이번 블록에서 투표 기간이 끝난 제안을 집계하고 결과 이벤트를 반환합니다.
+// FinalizeBlock이 모든 트랜잭션을 실행한 뒤 호출하며, 제안 ID 순으로 처리해 결과가 결정적입니다.
+func (app *PoliticianApp) tallyEndedProposals() []types.Event {
+	var ended []string
+	for id, proposal := range app.proposals {
+		if proposal.Status == ptypes.ProposalStatusVoting && proposal.VotingEndHeight <= app.exec.height {
+			ended = append(ended, id)
+		}
+	}
+	sort.Strings(ended)
+	events := make([]types.Event, 0, len(ended))
+	for _, id := range ended {
+		events = append(events, app.closeProposal(app.proposals[id])...)
+	}
+	return events
+}
+
+// closeProposal은 집계 결과에 따라 제안을 실행하고 보증금을 처리한 뒤 제안을 닫습니다.
+// 정족수를 채우면 가결 또는 부결되고 보증금을 돌려주며, 채우지 못하면 만료되고 보증금을 소각합니다.
+// 닫힌 제안은 지우지 않고 상태와 함께 기록으로 남깁니다.
+func (app *PoliticianApp) closeProposal(proposal *ptypes.Proposal) []types.Event {
+	// 가중 방식의 표는 집계 시점의 보유량으로 계산하므로, 투표 뒤 코인을 다른 계정에 넘겨 다시 투표해도 같은 코인이 두 번 세어지지 않습니다.
+	tally := tallyProposal(proposal, app.govParams, app.coinHoldings)
+	status := ptypes.ProposalStatusExpired
+	if tally.QuorumReached {
+		status = ptypes.ProposalStatusRejected
+	}
+	var events []types.Event
+	if tally.Passed {
+		// 투표 중에 상태가 바뀌어 실행할 수 없게 된 제안(먼저 등록된 정치인, 이미 상장 폐지된 정치인)은 부결로 닫습니다.
+		if executed, reason := app.executeProposal(proposal, &events); executed {
+			status = ptypes.ProposalStatusPassed
+		} else {
+			app.logger.Info("Proposal passed but could not be executed", "proposal_id", proposal.ID, "politician_id", proposal.Politician.ID, "reason", reason)
+		}
+	}
+
+	deposit := "burned"
+	if tally.QuorumReached {
+		deposit = "refunded"
+		if proposer, exists := app.accounts[proposal.Proposer]; exists {
+			proposer.USDTBalance += proposal.Deposit
+			app.touchAccount(proposal.Proposer)
+		}
+	}
+
+	proposal.Status = status
+	proposal.ClosedHeight = app.exec.height
+	app.touchProposal(proposal.ID)
+	app.logger.Info("Proposal tallied",
+		"proposal_id", proposal.ID,
+		"proposal_type", proposal.Type,
+		"politician_id", proposal.Politician.ID,
+		"result", status,
+		"voters", tally.Voters,
+		"yes_votes", tally.Yes,
+		"no_votes", tally.No,
+		"tally_mode", tally.Mode,
+		"yes_weight", tally.YesWeight,
+		"no_weight", tally.NoWeight,
+		"deposit", deposit)
+
+	return append([]types.Event{{
+		Type: "proposal_tallied",
+		Attributes: []types.EventAttribute{
+			{Key: "proposal_id", Value: proposal.ID, Index: true},
+			{Key: "proposal_type", Value: proposal.Type, Index: true},
+			{Key: "politician_id", Value: proposal.Politician.ID.String(), Index: true},
+			{Key: "result", Value: status, Index: true},
+			{Key: "voters", Value: strconv.FormatInt(tally.Voters, 10)},
+			{Key: "yes_votes", Value: strconv.FormatInt(tally.Yes, 10)},
+			{Key: "no_votes", Value: strconv.FormatInt(tally.No, 10)},
+			{Key: "tally_mode", Value: string(tally.Mode)},
+			{Key: "yes_weight", Value: strconv.FormatInt(tally.YesWeight, 10)},
+			{Key: "no_weight", Value: strconv.FormatInt(tally.NoWeight, 10)},
+			{Key: "deposit", Value: deposit},
+		},
+	}}, events...)
+}
+
+// executeProposal은 가결된 제안을 종류에 따라 실행하고, 실행하지 못하면 그 이유를 반환합니다.
+func (app *PoliticianApp) executeProposal(proposal *ptypes.Proposal, events *[]types.Event) (bool, string) {
+	existing, exists := app.politicianByID(proposal.Politician.ID)
+	switch proposal.Type {
+	case ptypes.ProposalTypeAmend:
+		if !exists || existing.Delisted {
+			return false, "politician is not listed"
+		}
+		existing.Region = proposal.Politician.Region
+		existing.Party = proposal.Politician.Party
+		existing.IntroUrl = proposal.Politician.IntroUrl
+		app.touchPolitician(string(existing.ID))
+		// 지역과 정당이 바뀌었으므로 색인을 다시 만듭니다.
+		app.reindexPoliticians()
+		return true, ""
+	case ptypes.ProposalTypeDelist:
+		if !exists || existing.Delisted {
+			return false, "politician is not listed"
+		}
+		*events = append(*events, app.delistPolitician(existing))
+		return true, ""
+	default:
+		if exists {
+			return false, "politician is already registered"
+		}
+		politician := proposal.Politician
+		politician.Supporters = []string{}
+		politician.TotalCoinSupply = ptypes.DefaultPoliticianCoinSupply
+		politician.RemainingCoins = ptypes.DefaultPoliticianCoinSupply
+		politician.DistributedCoins = 0
+		app.registerPolitician(&politician)
+		return true, ""
+	}
+}
+
+// delistPolitician은 정치인을 상장 폐지합니다. 정치인은 거래와 제안 기록을 위해 상태에 남지만
+//   - 미체결 주문은 접수 순서대로 모두 취소하고 남은 에스크로를 돌려주며,
+//   - 계정이 보유한 해당 정치인 코인은 소각하고, 남은 발행량도 0으로 만들어 더 배포하지 않으며,
+//   - 계정의 지지 목록과 정치인의 지지자 목록에서 서로를 지웁니다.
+//
+// 이후 이 정치인에 대한 주문, 추천 보상, 지지 선택, 수정·상장 폐지 제안은 거부됩니다.
+func (app *PoliticianApp) delistPolitician(politician *ptypes.Politician) types.Event {
+	id := string(politician.ID)
+
+	open := app.openOrdersFor(politician.ID)
+	for _, order := range open {
+		app.cancelOrder(order, app.exec.time)
+	}
+
+	var burned, holders int64
+	for userID, account := range app.accounts {
+		changed := false
+		if amount, held := account.PoliticianCoins[id]; held {
+			burned += amount
+			if amount > 0 {
+				holders++
+			}
+			delete(account.PoliticianCoins, id)
+			changed = true
+		}
+		if _, frozen := account.EscrowAccount.FrozenPoliticianCoins[id]; frozen {
+			delete(account.EscrowAccount.FrozenPoliticianCoins, id)
+			changed = true
+		}
+		for i, supported := range account.Politicians {
+			if supported == id {
+				account.Politicians = append(account.Politicians[:i], account.Politicians[i+1:]...)
+				changed = true
+				break
+			}
+		}
+		if changed {
+			app.touchAccount(userID)
+		}
+	}
+
+	politician.Delisted = true
+	politician.DelistedHeight = app.exec.height
+	politician.RemainingCoins = 0
+	politician.Supporters = []string{}
+	app.touchPolitician(id)
+	app.logger.Info("Politician delisted", "politician_id", id, "orders_cancelled", len(open), "coins_burned", burned, "holders", holders)
+
+	return types.Event{
+		Type: "politician_delisted",
+		Attributes: []types.EventAttribute{
+			{Key: "politician_id", Value: id, Index: true},
+			{Key: "orders_cancelled", Value: strconv.Itoa(len(open))},
+			{Key: "coins_burned", Value: strconv.FormatInt(burned, 10)},
+			{Key: "holders", Value: strconv.FormatInt(holders, 10)},
+		},
+	}
+}
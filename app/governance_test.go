@@ -0,0 +1,366 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/libs/log"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+func TestTallyProposal(t *testing.T) {
+	params := ptypes.GovParams{Quorum: 2, ApprovalThreshold: 50}
+	holdings := map[string]int64{"whale": 100, "small": 9, "mid": 144, "empty": 0}
+	holdingsOf := func(voter string) int64 { return holdings[voter] }
+
+	tests := []struct {
+		name       string
+		mode       ptypes.TallyMode
+		params     ptypes.GovParams
+		votes      map[string]bool
+		wantMode   ptypes.TallyMode
+		wantYes    int64
+		wantNo     int64
+		wantQuorum bool
+		wantPassed bool
+	}{
+		{
+			name:     "empty mode counts one vote per account",
+			votes:    map[string]bool{"whale": true, "small": true, "mid": false},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantYes: 2, wantNo: 1, wantQuorum: true, wantPassed: true,
+		},
+		{
+			name:     "one account one vote tie does not pass",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			votes:    map[string]bool{"whale": true, "small": false},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantYes: 1, wantNo: 1, wantQuorum: true,
+		},
+		{
+			name:     "below quorum never passes",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			votes:    map[string]bool{"whale": true},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantYes: 1,
+		},
+		{
+			name:     "no votes",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			votes:    map[string]bool{},
+			wantMode: ptypes.TallyModeOneAccountOneVote,
+		},
+		{
+			name:     "coin weighted outweighs head count",
+			mode:     ptypes.TallyModeCoinWeighted,
+			votes:    map[string]bool{"whale": true, "small": false, "empty": false},
+			wantMode: ptypes.TallyModeCoinWeighted, wantYes: 100, wantNo: 9, wantQuorum: true, wantPassed: true,
+		},
+		{
+			name:     "coin weighted loses to a larger holder",
+			mode:     ptypes.TallyModeCoinWeighted,
+			votes:    map[string]bool{"whale": true, "mid": false},
+			wantMode: ptypes.TallyModeCoinWeighted, wantYes: 100, wantNo: 144, wantQuorum: true,
+		},
+		{
+			name:     "zero holders reach quorum but cast no weight",
+			mode:     ptypes.TallyModeCoinWeighted,
+			votes:    map[string]bool{"empty": true, "nobody": true},
+			wantMode: ptypes.TallyModeCoinWeighted, wantQuorum: true,
+		},
+		{
+			name:     "quadratic uses the floor of the square root",
+			mode:     ptypes.TallyModeQuadratic,
+			votes:    map[string]bool{"whale": true, "small": false},
+			wantMode: ptypes.TallyModeQuadratic, wantYes: 10, wantNo: 3, wantQuorum: true, wantPassed: true,
+		},
+		{
+			name:     "quadratic loses to a larger holder",
+			mode:     ptypes.TallyModeQuadratic,
+			votes:    map[string]bool{"whale": true, "mid": false},
+			wantMode: ptypes.TallyModeQuadratic, wantYes: 10, wantNo: 12, wantQuorum: true,
+		},
+		{
+			name:     "zero holders under quadratic",
+			mode:     ptypes.TallyModeQuadratic,
+			votes:    map[string]bool{"empty": true, "nobody": false},
+			wantMode: ptypes.TallyModeQuadratic, wantQuorum: true,
+		},
+		{
+			name:     "yes share exactly at the threshold does not pass",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			params:   ptypes.GovParams{Quorum: 4, ApprovalThreshold: 75},
+			votes:    map[string]bool{"a": true, "b": true, "c": true, "d": false},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantYes: 3, wantNo: 1, wantQuorum: true,
+		},
+		{
+			name:     "yes share just above the threshold passes",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			params:   ptypes.GovParams{Quorum: 4, ApprovalThreshold: 74},
+			votes:    map[string]bool{"a": true, "b": true, "c": true, "d": false},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantYes: 3, wantNo: 1, wantQuorum: true, wantPassed: true,
+		},
+		{
+			name:     "zero threshold still needs a yes vote",
+			mode:     ptypes.TallyModeOneAccountOneVote,
+			params:   ptypes.GovParams{Quorum: 1, ApprovalThreshold: 0},
+			votes:    map[string]bool{"a": false},
+			wantMode: ptypes.TallyModeOneAccountOneVote, wantNo: 1, wantQuorum: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := params
+			if tt.params != (ptypes.GovParams{}) {
+				p = tt.params
+			}
+			got := tallyProposal(&ptypes.Proposal{TallyMode: tt.mode, Votes: tt.votes}, p, holdingsOf)
+			if got.Mode != tt.wantMode {
+				t.Errorf("mode = %s, want %s", got.Mode, tt.wantMode)
+			}
+			if got.Voters != int64(len(tt.votes)) {
+				t.Errorf("voters = %d, want %d", got.Voters, len(tt.votes))
+			}
+			if got.YesWeight != tt.wantYes || got.NoWeight != tt.wantNo {
+				t.Errorf("weights = %d/%d, want %d/%d", got.YesWeight, got.NoWeight, tt.wantYes, tt.wantNo)
+			}
+			if got.QuorumReached != tt.wantQuorum {
+				t.Errorf("quorum reached = %v, want %v", got.QuorumReached, tt.wantQuorum)
+			}
+			if got.Passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v", got.Passed, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	tests := []struct {
+		n, want int64
+	}{
+		{-4, 0},
+		{0, 0},
+		{1, 1},
+		{2, 1},
+		{3, 1},
+		{4, 2},
+		{8, 2},
+		{9, 3},
+		{99, 9},
+		{100, 10},
+		{101, 10},
+		{1<<62 - 1, 1<<31 - 1},
+		{1 << 62, 1 << 31},
+		{3_037_000_499 * 3_037_000_499, 3_037_000_499},
+		{3_037_000_499*3_037_000_499 - 1, 3_037_000_498},
+		{math.MaxInt64, 3_037_000_499},
+	}
+	for _, tt := range tests {
+		if got := isqrt(tt.n); got != tt.want {
+			t.Errorf("isqrt(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCloseProposalDeposit(t *testing.T) {
+	const deposit = 100
+	tests := []struct {
+		name        string
+		votes       map[string]bool
+		wantStatus  string
+		wantBalance int64
+		wantListed  bool
+	}{
+		{
+			name:        "passed proposal registers the politician and refunds the deposit",
+			votes:       map[string]bool{"proposer": true, "voter": true},
+			wantStatus:  ptypes.ProposalStatusPassed,
+			wantBalance: deposit,
+			wantListed:  true,
+		},
+		{
+			name:        "rejected proposal refunds the deposit",
+			votes:       map[string]bool{"proposer": true, "voter": false},
+			wantStatus:  ptypes.ProposalStatusRejected,
+			wantBalance: deposit,
+		},
+		{
+			name:        "expired proposal burns the deposit",
+			votes:       map[string]bool{"proposer": true},
+			wantStatus:  ptypes.ProposalStatusExpired,
+			wantBalance: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewPoliticianApp(dbm.NewMemDB(), log.NewNopLogger())
+			app.govParams = ptypes.GovParams{Quorum: 2, ApprovalThreshold: 50, VotingPeriod: 1}
+			app.accounts["proposer"] = &ptypes.Account{Address: "proposer"}
+			app.accounts["voter"] = &ptypes.Account{Address: "voter"}
+			app.exec.height = 7
+			id := ptypes.NewPoliticianID("홍길동", "서울")
+			proposal := &ptypes.Proposal{
+				ID:         "p1",
+				Type:       ptypes.ProposalTypeRegister,
+				Politician: ptypes.Politician{ID: id, Name: "홍길동", Region: "서울"},
+				Proposer:   "proposer",
+				Votes:      tt.votes,
+				Deposit:    deposit,
+				Status:     ptypes.ProposalStatusVoting,
+			}
+			app.proposals[proposal.ID] = proposal
+
+			events := app.closeProposal(proposal)
+
+			if proposal.Status != tt.wantStatus || proposal.ClosedHeight != 7 {
+				t.Errorf("status = %s at %d, want %s at 7", proposal.Status, proposal.ClosedHeight, tt.wantStatus)
+			}
+			if got := app.accounts["proposer"].USDTBalance; got != tt.wantBalance {
+				t.Errorf("proposer balance = %d, want %d", got, tt.wantBalance)
+			}
+			if _, listed := app.politicians[string(id)]; listed != tt.wantListed {
+				t.Errorf("politician listed = %v, want %v", listed, tt.wantListed)
+			}
+			if len(events) == 0 || events[0].Type != "proposal_tallied" {
+				t.Fatalf("events = %v, want proposal_tallied first", events)
+			}
+		})
+	}
+}
+
+// governanceChain은 정치인 한 명과 보증금을 낼 수 있는 제안자, 투표자가 있는 테스트 체인을 만듭니다.
+// 투표 기간은 2블록이고 한 표로 정족수를 채웁니다.
+func governanceChain(t *testing.T) (*testChain, ptypes.PoliticianID) {
+	t.Helper()
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울", Party: "무소속"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"proposer": {Address: "proposer", USDTBalance: 1_000},
+			"voter":    {Address: "voter", USDTBalance: 1_000},
+		},
+		GovParams: &ptypes.GovParams{Quorum: 1, ApprovalThreshold: 50, VotingPeriod: 2, ProposerDeposit: 100},
+	})
+	return chain, politician.ID
+}
+
+// 수정·상장 폐지 제안은 타입이 있는 메시지로 대상과 새 정보를 받고, 가결되면 정치인에게 적용됩니다.
+func TestAmendAndDelistProposals(t *testing.T) {
+	chain, id := governanceChain(t)
+	vote := func(proposalID string) ptypes.TxData {
+		return ptypes.TxData{Action: "vote_on_proposal", UserID: "voter", ProposalID: proposalID, Vote: true}
+	}
+
+	// 메시지 없이 정치인 필드에 담은 요청은 받지 않습니다.
+	legacy := chain.exec(ptypes.TxData{Action: "propose_amendment", UserID: "proposer", PoliticianName: string(id), Region: "부산"})
+	if legacy[0].Code == 0 {
+		t.Fatal("amendment without a message was accepted")
+	}
+	missing := chain.exec(ptypes.TxData{Action: "propose_delisting", UserID: "proposer", Msg: &ptypes.TxMsg{
+		ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: "없는-정치인"},
+	}})
+	if missing[0].Code != 60 {
+		t.Fatalf("delisting an unknown politician: code %d %s, want 60", missing[0].Code, missing[0].Log)
+	}
+
+	amend := chain.block(ptypes.TxData{Action: "propose_amendment", UserID: "proposer", Msg: &ptypes.TxMsg{
+		ProposeAmendment: &ptypes.ProposeAmendmentMsg{PoliticianID: id, Region: " 서울 ", Party: "새정당", IntroUrl: "https://example.com/hong"},
+	}})
+	chain.block(vote(string(amend[0].Data)))
+	chain.block()
+	politician := chain.app.politicians[string(id)]
+	if politician.Region != "서울" || politician.Party != "새정당" || politician.IntroUrl != "https://example.com/hong" {
+		t.Fatalf("amended politician = %+v", politician)
+	}
+
+	delist := chain.block(ptypes.TxData{Action: "propose_delisting", UserID: "proposer", Msg: &ptypes.TxMsg{
+		ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: id},
+	}})
+	chain.block(vote(string(delist[0].Data)))
+	chain.block()
+	if !chain.app.politicians[string(id)].Delisted {
+		t.Fatal("politician was not delisted")
+	}
+	again := chain.exec(ptypes.TxData{Action: "propose_delisting", UserID: "proposer", Msg: &ptypes.TxMsg{
+		ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: id},
+	}})
+	if again[0].Code != 62 {
+		t.Fatalf("delisting a delisted politician: code %d %s, want 62", again[0].Code, again[0].Log)
+	}
+}
+
+// 제안자는 투표 중인 제안을 철회할 수 있습니다. 철회한 제안은 보증금을 돌려받지 못하고(만료와 같이 소각),
+// 철회 상태로 기록에 남아 집계되지 않습니다.
+func TestWithdrawProposal(t *testing.T) {
+	chain, id := governanceChain(t)
+	withdraw := func(userID, proposalID string) ptypes.TxData {
+		return ptypes.TxData{Action: "withdraw_proposal", UserID: userID, Msg: &ptypes.TxMsg{
+			WithdrawProposal: &ptypes.WithdrawProposalMsg{ProposalID: proposalID},
+		}}
+	}
+	proposalID := string(chain.block(ptypes.TxData{Action: "propose_delisting", UserID: "proposer", Msg: &ptypes.TxMsg{
+		ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: id},
+	}})[0].Data)
+	if got := chain.app.accounts["proposer"].USDTBalance; got != 900 {
+		t.Fatalf("proposer balance after proposing = %d, want 900", got)
+	}
+
+	// 잘못된 철회 요청은 한 블록에 담아 투표 기간 안에 확인합니다.
+	rejected := []struct {
+		name     string
+		tx       ptypes.TxData
+		wantCode uint32
+	}{
+		{name: "without a message", tx: ptypes.TxData{Action: "withdraw_proposal", UserID: "proposer", ProposalID: proposalID}, wantCode: 15},
+		{name: "unknown proposal", tx: withdraw("proposer", "없는-제안"), wantCode: 26},
+		{name: "not the proposer", tx: withdraw("voter", proposalID), wantCode: 27},
+	}
+	txs := make([]ptypes.TxData, len(rejected))
+	for i, tt := range rejected {
+		txs[i] = tt.tx
+	}
+	for i, res := range chain.exec(txs...) {
+		if res.Code != rejected[i].wantCode {
+			t.Fatalf("%s: code %d %s, want %d", rejected[i].name, res.Code, res.Log, rejected[i].wantCode)
+		}
+	}
+
+	res := chain.block(withdraw("proposer", proposalID))[0]
+	proposal := chain.app.proposals[proposalID]
+	if proposal.Status != ptypes.ProposalStatusWithdrawn || proposal.ClosedHeight != chain.height {
+		t.Fatalf("proposal %s at %d, want withdrawn at %d", proposal.Status, proposal.ClosedHeight, chain.height)
+	}
+	if len(res.Events) != 1 || res.Events[0].Type != "proposal_withdrawn" {
+		t.Fatalf("events = %v, want proposal_withdrawn", res.Events)
+	}
+	if got := chain.app.accounts["proposer"].USDTBalance; got != 900 {
+		t.Fatalf("proposer balance after withdrawing = %d, want 900 (deposit burned)", got)
+	}
+
+	// 철회한 제안에는 투표하거나 다시 철회할 수 없고, 투표 기간이 지나도 집계되지 않습니다.
+	if res := chain.exec(ptypes.TxData{Action: "vote_on_proposal", UserID: "voter", ProposalID: proposalID, Vote: true})[0]; res.Code != 46 {
+		t.Fatalf("vote on a withdrawn proposal: code %d %s, want 46", res.Code, res.Log)
+	}
+	if res := chain.exec(withdraw("proposer", proposalID))[0]; res.Code != 28 {
+		t.Fatalf("second withdrawal: code %d %s, want 28", res.Code, res.Log)
+	}
+	chain.block()
+	if proposal.Status != ptypes.ProposalStatusWithdrawn || chain.app.politicians[string(id)].Delisted {
+		t.Fatalf("withdrawn proposal was tallied: status %s", proposal.Status)
+	}
+	if got := chain.app.accounts["proposer"].USDTBalance; got != 900 {
+		t.Fatalf("proposer balance after the voting period = %d, want 900", got)
+	}
+
+	// 닫힌 제안은 기록으로 남아 상태별 목록에서 찾을 수 있습니다.
+	if got := proposalKeys(chain.app, url.Values{"status": {ptypes.ProposalStatusWithdrawn}}); got != fmt.Sprint([]string{proposalID}) {
+		t.Fatalf("withdrawn proposals = %s, want [%s]", got, proposalID)
+	}
+	if got := proposalKeys(chain.app, url.Values{"status": {ptypes.ProposalStatusVoting}}); got != "[]" {
+		t.Fatalf("voting proposals = %s, want none", got)
+	}
+}
@@ -0,0 +1,487 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/cometbft/cometbft/abci/types"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 체결 과정에서 한쪽 당사자가 결제를 할 수 없을 때 반환되는 오류입니다.
+var (
+	errBuyerCannotSettle  = errors.New("buyer cannot settle trade")
+	errSellerCannotSettle = errors.New("seller cannot settle trade")
+)
+
+// isOpenOrder는 오더북에 남아 체결될 수 있는 주문인지 확인합니다.
+func isOpenOrder(order *ptypes.TradeOrder) bool {
+	return order.Status == "active" || order.Status == "partial"
+}
+
+// remainingQuantity는 아직 체결되지 않은 수량을 반환합니다.
+func remainingQuantity(order *ptypes.TradeOrder) int64 {
+	return order.Quantity - order.FilledQuantity
+}
+
+// restingOrders는 taker 주문과 체결될 수 있는 반대편 호가를 가격-시간 우선순위로 정렬해 반환합니다.
+// 매수 주문에는 낮은 가격의 매도 주문이, 매도 주문에는 높은 가격의 매수 주문이 먼저 옵니다.
+// 같은 가격이면 먼저 접수된(Sequence가 작은) 주문이 우선합니다.
+func (app *PoliticianApp) restingOrders(taker *ptypes.TradeOrder) []*ptypes.TradeOrder {
+	side := "sell"
+	if taker.OrderType == "sell" {
+		side = "buy"
+	}
+	var book []*ptypes.TradeOrder
+	for _, order := range app.orderBooks.books[bookKey{politicianID: taker.PoliticianID, side: side, currency: taker.Currency}] {
+		if order.ID == taker.ID {
+			continue
+		}
+		// 만료 시각이 지났지만 아직 블록 끝에서 정리되지 않은 주문과는 체결하지 않습니다.
+		if orderExpiredBefore(order, app.exec.height, app.exec.time) {
+			continue
+		}
+		// 자기 자신의 주문과는 체결하지 않습니다.
+		if order.UserID == taker.UserID {
+			continue
+		}
+		book = append(book, order)
+	}
+	sort.Slice(book, func(i, j int) bool {
+		if book[i].Price != book[j].Price {
+			if side == "sell" {
+				return book[i].Price < book[j].Price
+			}
+			return book[i].Price > book[j].Price
+		}
+		if book[i].Sequence != book[j].Sequence {
+			return book[i].Sequence < book[j].Sequence
+		}
+		return book[i].ID < book[j].ID
+	})
+	return book
+}
+
+// crosses는 taker 주문이 maker 주문 가격과 만나는지 확인합니다.
+func crosses(taker, maker *ptypes.TradeOrder) bool {
+	if taker.OrderType == "buy" {
+		return taker.Price >= maker.Price
+	}
+	return taker.Price <= maker.Price
+}
+
+// crossesBook은 주문이 지금 오더북의 최우선 반대 호가와 바로 체결되는 가격인지 확인합니다.
+func (app *PoliticianApp) crossesBook(order *ptypes.TradeOrder) bool {
+	book := app.restingOrders(order)
+	return len(book) > 0 && crosses(order, book[0])
+}
+
+// fillableQuantity는 주문이 지금 오더북과 즉시 체결할 수 있는 수량을 주문 수량 한도 안에서 반환합니다.
+// matchOrder와 같은 순서로 maker를 따라가며, 결제할 수 없어 matchOrder가 건너뛰고 취소할 maker는 세지 않습니다.
+// 같은 계정의 maker 여럿이 동결되지 않은 잔액을 나눠 쓰는 경우도 앞선 체결이 쓴 만큼 빼고 확인합니다.
+func (app *PoliticianApp) fillableQuantity(order *ptypes.TradeOrder) int64 {
+	var fillable int64
+	spent := make(map[string]int64) // maker 계정별로 앞선 체결이 동결되지 않은 잔액에서 쓴 양
+	for _, maker := range app.restingOrders(order) {
+		if fillable >= order.Quantity || !crosses(order, maker) {
+			break
+		}
+		account, exists := app.accounts[maker.UserID]
+		if !exists {
+			continue
+		}
+		quantity := min(remainingQuantity(maker), order.Quantity-fillable)
+		need := quantity
+		if maker.OrderType == "buy" {
+			need = quantity * maker.Price
+		}
+		available, reserved := settleAvailable(account, maker, quantity)
+		if available-spent[maker.UserID] < need {
+			// matchOrder는 이 maker를 취소하므로, 동결되어 있던 나머지가 같은 계정의 다음 maker에 쓰일 수 있습니다.
+			if hasEscrowLocked(account, maker.ID) {
+				released := remainingQuantity(maker)
+				if maker.OrderType == "buy" {
+					released *= maker.Price
+				}
+				spent[maker.UserID] -= released
+			}
+			continue
+		}
+		spent[maker.UserID] += need - reserved
+		fillable += quantity
+	}
+	return fillable
+}
+
+// priceMarketOrder는 시장가 주문의 가격을 체결 한계 가격으로 정합니다. 한계 가격은 최우선 반대 호가에서
+// 허용 슬리피지만큼 불리한 가격이고(슬리피지 금액은 내림), 주문은 이 가격까지 오더북을 쓸어 체결합니다.
+// 매수 주문의 에스크로도 이 가격으로 잡습니다. 반대 호가가 없으면 false를 반환합니다.
+func (app *PoliticianApp) priceMarketOrder(order *ptypes.TradeOrder) bool {
+	book := app.restingOrders(order)
+	if len(book) == 0 {
+		return false
+	}
+	best := book[0].Price
+	// best × bps / 10000을 오버플로 없이 계산합니다.
+	slippage := best/ptypes.MaxSlippageBps*order.MaxSlippageBps + best%ptypes.MaxSlippageBps*order.MaxSlippageBps/ptypes.MaxSlippageBps
+	if order.OrderType == "buy" {
+		if best > math.MaxInt64-slippage {
+			order.Price = math.MaxInt64
+		} else {
+			order.Price = best + slippage
+		}
+	} else {
+		order.Price = best - slippage
+	}
+	return true
+}
+
+// matchOrder는 새로 접수된 주문을 오더북과 가격-시간 우선순위로 체결합니다.
+// 체결 가격은 먼저 오더북에 있던 maker 주문의 가격입니다. 모든 노드가 같은 상태에서
+// 같은 순서로 실행하므로 체결 결과는 결정적입니다.
+func (app *PoliticianApp) matchOrder(taker *ptypes.TradeOrder) []types.Event {
+	var events []types.Event
+	for _, maker := range app.restingOrders(taker) {
+		if remainingQuantity(taker) <= 0 {
+			break
+		}
+		if !crosses(taker, maker) {
+			break
+		}
+
+		quantity := remainingQuantity(taker)
+		if makerRemaining := remainingQuantity(maker); makerRemaining < quantity {
+			quantity = makerRemaining
+		}
+
+		buyOrder, sellOrder := taker, maker
+		if taker.OrderType == "sell" {
+			buyOrder, sellOrder = maker, taker
+		}
+
+		trade, err := app.settleTrade(buyOrder, sellOrder, quantity, maker.Price, app.exec.time)
+		if err != nil {
+			// maker가 결제할 수 없으면 해당 주문을 취소하고 다음 호가로 넘어갑니다.
+			// taker가 결제할 수 없으면 더 이상 체결하지 않습니다.
+			takerFailed := (taker.OrderType == "buy" && errors.Is(err, errBuyerCannotSettle)) ||
+				(taker.OrderType == "sell" && errors.Is(err, errSellerCannotSettle))
+			if takerFailed {
+				app.logger.Info("Taker cannot settle, stop matching", "order_id", taker.ID, "error", err)
+				break
+			}
+			app.logger.Info("Maker cannot settle, cancelling order", "order_id", maker.ID, "error", err)
+			app.cancelOrder(maker, app.exec.time)
+			continue
+		}
+		events = append(events, tradeEvent(trade))
+	}
+	return events
+}
+
+// settleTrade는 매수/매도 주문 사이의 체결 한 건을 주문의 결제 통화로 결제하고 거래 기록을 남깁니다.
+// 두 주문은 같은 오더북(정치인, 결제 통화)에 있으므로 결제 통화가 같습니다.
+// 에스크로가 이미 동결된 주문은 체결된 만큼의 동결 금액을 함께 해제합니다.
+func (app *PoliticianApp) settleTrade(buyOrder, sellOrder *ptypes.TradeOrder, quantity, price, timestamp int64) (*ptypes.Trade, error) {
+	buyerAccount, exists := app.accounts[buyOrder.UserID]
+	if !exists {
+		return nil, fmt.Errorf("%w: account %s not found", errBuyerCannotSettle, buyOrder.UserID)
+	}
+	sellerAccount, exists := app.accounts[sellOrder.UserID]
+	if !exists {
+		return nil, fmt.Errorf("%w: account %s not found", errSellerCannotSettle, sellOrder.UserID)
+	}
+	ensureEscrowAccount(buyerAccount)
+	ensureEscrowAccount(sellerAccount)
+
+	totalAmount := quantity * price
+	politicianID := string(buyOrder.PoliticianID)
+	currency := buyOrder.Currency
+	buyerBalance, buyerFrozen := quoteBalance(buyerAccount, currency), frozenQuoteBalance(buyerAccount, currency)
+
+	// 동결된 에스크로는 주문 가격 기준으로 잡혀 있으므로 체결되는 수량만큼 해제합니다.
+	buyerAvailable, buyerReserved := settleAvailable(buyerAccount, buyOrder, quantity)
+	if buyerAvailable < totalAmount {
+		return nil, fmt.Errorf("%w: need %d, available %d", errBuyerCannotSettle, totalAmount, buyerAvailable)
+	}
+	sellerAvailable, sellerReserved := settleAvailable(sellerAccount, sellOrder, quantity)
+	if sellerAvailable < quantity {
+		return nil, fmt.Errorf("%w: need %d, available %d", errSellerCannotSettle, quantity, sellerAvailable)
+	}
+
+	// 1. 매수자에게서 결제 통화 차감 및 정치인 코인 지급
+	*buyerBalance -= totalAmount
+	*buyerFrozen -= buyerReserved
+	if buyerAccount.PoliticianCoins == nil {
+		buyerAccount.PoliticianCoins = make(map[string]int64)
+	}
+	buyerAccount.PoliticianCoins[politicianID] += quantity
+
+	// 2. 매도자에게서 정치인 코인 차감 및 결제 통화 지급
+	sellerAccount.PoliticianCoins[politicianID] -= quantity
+	sellerAccount.EscrowAccount.FrozenPoliticianCoins[politicianID] -= sellerReserved
+	*quoteBalance(sellerAccount, currency) += totalAmount
+
+	// 3. 주문 상태 업데이트
+	for _, fill := range []struct {
+		order   *ptypes.TradeOrder
+		account *ptypes.Account
+	}{{buyOrder, buyerAccount}, {sellOrder, sellerAccount}} {
+		fill.order.FilledQuantity += quantity
+		fill.order.UpdatedAt = timestamp
+		if fill.order.FilledQuantity >= fill.order.Quantity {
+			fill.order.Status = "filled"
+			// 매수 주문이 더 낮은 가격에 체결되어 남은 동결 금액이 있으면 함께 해제합니다.
+			app.releaseOrderEscrow(fill.account, fill.order)
+		} else {
+			fill.order.Status = "partial"
+		}
+		app.indexOrder(fill.order)
+	}
+
+	// 4. 거래 기록 저장
+	trade := &ptypes.Trade{
+		ID:           app.exec.newID("trade"),
+		BuyOrderID:   buyOrder.ID,
+		SellOrderID:  sellOrder.ID,
+		BuyerID:      buyOrder.UserID,
+		SellerID:     sellOrder.UserID,
+		PoliticianID: buyOrder.PoliticianID,
+		Currency:     currency,
+		Quantity:     quantity,
+		Price:        price,
+		TotalAmount:  totalAmount,
+		Timestamp:    timestamp,
+		Status:       "completed",
+	}
+	app.trades[trade.ID] = trade
+	app.touchTrade(trade.ID)
+	app.touchOrder(buyOrder.ID)
+	app.touchOrder(sellOrder.ID)
+	app.touchAccount(buyOrder.UserID)
+	app.touchAccount(sellOrder.UserID)
+
+	app.logger.Info("Trade executed successfully",
+		"trade_id", trade.ID,
+		"buyer", trade.BuyerID,
+		"seller", trade.SellerID,
+		"quantity", trade.Quantity,
+		"price", trade.Price,
+		"total_amount", trade.TotalAmount)
+	return trade, nil
+}
+
+// settleAvailable은 주문 쪽 계정이 quantity만큼 체결할 때 쓸 수 있는 잔액(매수는 결제 통화, 매도는 정치인 코인)과,
+// 그중 이 주문에 동결되어 있어 체결과 함께 해제되는 양을 반환합니다.
+func settleAvailable(account *ptypes.Account, order *ptypes.TradeOrder, quantity int64) (available, reserved int64) {
+	locked := hasEscrowLocked(account, order.ID)
+	if order.OrderType == "buy" {
+		if locked {
+			reserved = quantity * order.Price
+		}
+		return *quoteBalance(account, order.Currency) - *frozenQuoteBalance(account, order.Currency) + reserved, reserved
+	}
+	if locked {
+		reserved = quantity
+	}
+	politicianID := string(order.PoliticianID)
+	return account.PoliticianCoins[politicianID] - account.EscrowAccount.FrozenPoliticianCoins[politicianID] + reserved, reserved
+}
+
+// quoteBalance는 결제 통화(USDT, USDC)의 잔액 필드를 반환합니다.
+func quoteBalance(account *ptypes.Account, currency string) *int64 {
+	if currency == "USDC" {
+		return &account.USDCBalance
+	}
+	return &account.USDTBalance
+}
+
+// frozenQuoteBalance는 결제 통화(USDT, USDC)의 동결 잔액 필드를 반환합니다.
+func frozenQuoteBalance(account *ptypes.Account, currency string) *int64 {
+	if currency == "USDC" {
+		return &account.EscrowAccount.FrozenUSDCBalance
+	}
+	return &account.EscrowAccount.FrozenUSDTBalance
+}
+
+// lockOrderEscrow는 주문의 미체결 부분에 필요한 잔액(매수는 수량 × 가격의 결제 통화, 매도는 정치인 코인)을
+// 동결하고 활성 주문 목록에 추가합니다. 잔액이 충분한지는 호출하는 쪽에서 확인합니다.
+func lockOrderEscrow(account *ptypes.Account, order *ptypes.TradeOrder) {
+	ensureEscrowAccount(account)
+	order.EscrowAmount = requiredForOrder(order)
+	if order.OrderType == "buy" {
+		*frozenQuoteBalance(account, order.Currency) += order.EscrowAmount
+	} else {
+		account.EscrowAccount.FrozenPoliticianCoins[string(order.PoliticianID)] += order.EscrowAmount
+	}
+	account.EscrowAccount.ActiveOrders = append(account.EscrowAccount.ActiveOrders, order.ID)
+}
+
+// cancelOrder는 주문을 취소 상태로 바꾸고 남은 에스크로를 해제합니다.
+func (app *PoliticianApp) cancelOrder(order *ptypes.TradeOrder, timestamp int64) {
+	app.closeOrder(order, "cancelled", timestamp)
+}
+
+// closeOrder는 미체결 주문을 종료 상태(cancelled, expired)로 바꾸고 남은 에스크로를 해제합니다.
+func (app *PoliticianApp) closeOrder(order *ptypes.TradeOrder, status string, timestamp int64) {
+	order.Status = status
+	order.UpdatedAt = timestamp
+	app.touchOrder(order.ID)
+	app.indexOrder(order)
+	if account, exists := app.accounts[order.UserID]; exists {
+		ensureEscrowAccount(account)
+		app.releaseOrderEscrow(account, order)
+		app.touchAccount(order.UserID)
+	}
+}
+
+// orderExpiredBefore는 주문이 이 블록(높이, 블록 시간)이 시작되기 전에 이미 만료되었는지 확인합니다.
+// 주문은 만료 높이의 블록까지, 그리고 블록 시간이 만료 시각을 넘지 않는 블록까지 체결될 수 있습니다.
+func orderExpiredBefore(order *ptypes.TradeOrder, height, blockTime int64) bool {
+	return (order.ExpiresHeight != 0 && height > order.ExpiresHeight) || (order.ExpiresAt != 0 && blockTime > order.ExpiresAt)
+}
+
+// expiresInBlock은 주문이 이 블록(높이, 블록 시간) 끝에서 만료되는지 확인합니다.
+// 블록 끝의 만료 정리와 주문 접수 검증이 같은 규칙을 쓰므로, 접수된 주문은 적어도 다음 블록까지 오더북에 남습니다.
+func expiresInBlock(order *ptypes.TradeOrder, height, blockTime int64) bool {
+	return (order.ExpiresHeight != 0 && height >= order.ExpiresHeight) || (order.ExpiresAt != 0 && blockTime >= order.ExpiresAt)
+}
+
+// expireOrders는 블록 끝에서 만료 높이나 만료 시각에 이른 미체결 주문을 접수 순서대로 만료시키고
+// 남은 에스크로를 해제합니다. 다음 블록부터 체결될 수 없는 주문이 대상입니다.
+// 만료가 지정된 미체결 주문 색인만 살펴봅니다.
+func (app *PoliticianApp) expireOrders() []types.Event {
+	var expired []*ptypes.TradeOrder
+	for _, order := range app.orderBooks.expiring {
+		if expiresInBlock(order, app.exec.height, app.exec.time) {
+			expired = append(expired, order)
+		}
+	}
+	sortBySequence(expired)
+
+	events := make([]types.Event, 0, len(expired))
+	for _, order := range expired {
+		app.closeOrder(order, "expired", app.exec.time)
+		app.logger.Info("Order expired", "order_id", order.ID, "user_id", order.UserID, "filled_quantity", order.FilledQuantity)
+		events = append(events, orderExpiredEvent(order))
+	}
+	return events
+}
+
+// releaseOrderEscrow는 주문에 대해 아직 동결되어 있는 에스크로를 해제하고
+// 활성 주문 목록에서 제거합니다. 동결된 적이 없는 주문이면 아무것도 하지 않습니다.
+func (app *PoliticianApp) releaseOrderEscrow(account *ptypes.Account, order *ptypes.TradeOrder) {
+	if !hasEscrowLocked(account, order.ID) {
+		return
+	}
+	remaining := remainingQuantity(order)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if order.OrderType == "buy" {
+		frozen := frozenQuoteBalance(account, order.Currency)
+		*frozen -= remaining * order.Price
+		if *frozen < 0 {
+			*frozen = 0
+		}
+	} else {
+		politicianID := string(order.PoliticianID)
+		account.EscrowAccount.FrozenPoliticianCoins[politicianID] -= remaining
+		if account.EscrowAccount.FrozenPoliticianCoins[politicianID] < 0 {
+			account.EscrowAccount.FrozenPoliticianCoins[politicianID] = 0
+		}
+	}
+	removeActiveOrder(account, order.ID)
+}
+
+// hasEscrowLocked는 주문의 에스크로가 동결되어 있는지(활성 주문 목록에 있는지) 확인합니다.
+func hasEscrowLocked(account *ptypes.Account, orderID string) bool {
+	for _, id := range account.EscrowAccount.ActiveOrders {
+		if id == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeActiveOrder는 에스크로 활성 주문 목록에서 주문 ID를 제거합니다.
+func removeActiveOrder(account *ptypes.Account, orderID string) {
+	for i, id := range account.EscrowAccount.ActiveOrders {
+		if id == orderID {
+			account.EscrowAccount.ActiveOrders = append(account.EscrowAccount.ActiveOrders[:i], account.EscrowAccount.ActiveOrders[i+1:]...)
+			return
+		}
+	}
+}
+
+// ensureEscrowAccount는 에스크로 계정의 맵과 슬라이스를 초기화합니다.
+func ensureEscrowAccount(account *ptypes.Account) {
+	if account.EscrowAccount.FrozenPoliticianCoins == nil {
+		account.EscrowAccount.FrozenPoliticianCoins = make(map[string]int64)
+	}
+	if account.EscrowAccount.ActiveOrders == nil {
+		account.EscrowAccount.ActiveOrders = []string{}
+	}
+}
+
+// orderPlacedEvent는 접수된 주문을 ABCI 이벤트로 만듭니다.
+func orderPlacedEvent(order *ptypes.TradeOrder) types.Event {
+	return types.Event{
+		Type: "order_placed",
+		Attributes: []types.EventAttribute{
+			{Key: "order_id", Value: order.ID, Index: true},
+			{Key: "politician_id", Value: order.PoliticianID.String(), Index: true},
+			{Key: "owner", Value: order.UserID, Index: true},
+			{Key: "order_type", Value: order.OrderType},
+			{Key: "quantity", Value: strconv.FormatInt(order.Quantity, 10)},
+			{Key: "price", Value: strconv.FormatInt(order.Price, 10)},
+			{Key: "kind", Value: order.Kind},
+			{Key: "time_in_force", Value: order.TimeInForce},
+		},
+	}
+}
+
+// orderClosedEvent는 IOC, FOK, 시장가 주문이 즉시 체결되지 않은 나머지를 남기지 않고 종료되었음을 알리는 이벤트입니다.
+func orderClosedEvent(order *ptypes.TradeOrder) types.Event {
+	return types.Event{
+		Type: "order_closed",
+		Attributes: []types.EventAttribute{
+			{Key: "order_id", Value: order.ID, Index: true},
+			{Key: "owner", Value: order.UserID, Index: true},
+			{Key: "status", Value: order.Status},
+			{Key: "filled_quantity", Value: strconv.FormatInt(order.FilledQuantity, 10)},
+		},
+	}
+}
+
+// orderExpiredEvent는 만료된 주문을 알리는 이벤트입니다.
+func orderExpiredEvent(order *ptypes.TradeOrder) types.Event {
+	return types.Event{
+		Type: "order_expired",
+		Attributes: []types.EventAttribute{
+			{Key: "order_id", Value: order.ID, Index: true},
+			{Key: "politician_id", Value: order.PoliticianID.String(), Index: true},
+			{Key: "owner", Value: order.UserID, Index: true},
+			{Key: "filled_quantity", Value: strconv.FormatInt(order.FilledQuantity, 10)},
+		},
+	}
+}
+
+// tradeEvent는 체결 결과를 ABCI 이벤트로 만듭니다.
+func tradeEvent(trade *ptypes.Trade) types.Event {
+	return types.Event{
+		Type: "trade",
+		Attributes: []types.EventAttribute{
+			{Key: "trade_id", Value: trade.ID, Index: true},
+			{Key: "politician_id", Value: trade.PoliticianID.String(), Index: true},
+			{Key: "buy_order_id", Value: trade.BuyOrderID},
+			{Key: "sell_order_id", Value: trade.SellOrderID},
+			{Key: "buyer", Value: trade.BuyerID, Index: true},
+			{Key: "seller", Value: trade.SellerID, Index: true},
+			{Key: "quantity", Value: strconv.FormatInt(trade.Quantity, 10)},
+			{Key: "price", Value: strconv.FormatInt(trade.Price, 10)},
+		},
+	}
+}
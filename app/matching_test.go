@@ -0,0 +1,325 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 주문은 접수되는 블록 끝에서 바로 만료되지 않아야 접수되고, 만료 높이·시각에 이른 블록 끝에서 만료됩니다.
+// FinalizeBlock과 블록 끝 만료 정리는 같은 규칙을 쓰고, CheckTx는 마지막 블록 시간으로 같은 규칙을 적용합니다.
+func TestOrderExpiryBoundary(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresHeight func(height int64) int64 // 접수되는 블록 높이로부터 만료 높이
+		expiresAt     func(height int64) int64 // 접수되는 블록 높이로부터 만료 시각
+		wantCode      uint32
+	}{
+		{name: "expires at the placing height", expiresHeight: func(h int64) int64 { return h }, wantCode: 17},
+		{name: "expires before the placing height", expiresHeight: func(h int64) int64 { return h - 1 }, wantCode: 17},
+		{name: "expires at the next height", expiresHeight: func(h int64) int64 { return h + 1 }},
+		{name: "expires at the placing block time", expiresAt: func(h int64) int64 { return blockTime(h) }, wantCode: 17},
+		{name: "expires after the placing block time", expiresAt: func(h int64) int64 { return blockTime(h) + 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+			roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+			if err != nil {
+				t.Fatal(err)
+			}
+			chain := newTestChain(t, ptypes.GenesisState{
+				Politicians: roster,
+				Accounts:    map[string]*ptypes.Account{"buyer": {Address: "buyer", USDTBalance: 1000}},
+			})
+			chain.block() // 높이 1
+
+			placing := chain.height + 1
+			msg := ptypes.PlaceOrderMsg{PoliticianID: politician.ID, OrderType: "buy", Currency: "USDT", Quantity: 1, Price: 100}
+			if tt.expiresHeight != nil {
+				msg.ExpiresHeight = tt.expiresHeight(placing)
+			}
+			if tt.expiresAt != nil {
+				msg.ExpiresAt = tt.expiresAt(placing)
+			}
+			tx := placeOrder("buyer", msg)
+
+			// CheckTx는 마지막 블록 시간으로 확인하므로 시각 경계의 주문을 아직 거부하지 못합니다.
+			check, err := chain.app.CheckTx(context.Background(), &types.RequestCheckTx{Tx: chain.sign(tx)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			chain.nonces["buyer"]--
+			wantCheck := tt.wantCode
+			if tt.expiresAt != nil {
+				wantCheck = 0
+			}
+			if check.Code != wantCheck {
+				t.Errorf("CheckTx code = %d (%s), want %d", check.Code, check.Log, wantCheck)
+			}
+
+			result := chain.exec(tx)[0]
+			if result.Code != tt.wantCode {
+				t.Fatalf("FinalizeBlock code = %d (%s), want %d", result.Code, result.Log, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				return
+			}
+			order := chain.app.orders[string(result.Data)]
+			if order.Status != "active" {
+				t.Fatalf("status after the placing block = %s, want active", order.Status)
+			}
+			chain.block()
+			if order.Status != "expired" {
+				t.Fatalf("status after the next block = %s, want expired", order.Status)
+			}
+			if frozen := chain.app.accounts["buyer"].EscrowAccount.FrozenUSDTBalance; frozen != 0 {
+				t.Errorf("frozen USDT after expiry = %d, want 0", frozen)
+			}
+		})
+	}
+}
+
+// orderTypeChain은 seller가 100에 2개, 120에 3개를 매도 호가로 올려 둔 체인을 만듭니다.
+func orderTypeChain(t *testing.T) (*testChain, ptypes.PoliticianID) {
+	t.Helper()
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	politician.RemainingCoins -= 10
+	politician.DistributedCoins = 10
+	id := politician.ID
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"buyer":  {Address: "buyer", USDTBalance: 10_000},
+			"seller": {Address: "seller", PoliticianCoins: map[string]int64{string(id): 5}},
+			"other":  {Address: "other", PoliticianCoins: map[string]int64{string(id): 5}},
+		},
+	})
+	chain.block(
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 2, Price: 100}),
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 3, Price: 120}),
+	)
+	return chain, id
+}
+
+// 시장가, IOC, FOK, post-only 주문은 체결되는 만큼만 체결하고 오더북에 나머지를 남기지 않거나, 조건이 맞지 않으면 거부됩니다.
+func TestOrderTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        ptypes.PlaceOrderMsg
+		wantCode   uint32
+		wantStatus string
+		wantFilled int64
+		wantSpent  int64 // buyer가 낸 USDT
+	}{
+		{name: "IOC fills what crosses and cancels the rest",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 4, Price: 110, TimeInForce: "IOC"},
+			wantStatus: "cancelled", wantFilled: 2, wantSpent: 200},
+		{name: "IOC without a crossing price fills nothing",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 1, Price: 90, TimeInForce: "IOC"},
+			wantStatus: "cancelled"},
+		{name: "FOK fills across price levels",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 4, Price: 120, TimeInForce: "FOK"},
+			wantStatus: "filled", wantFilled: 4, wantSpent: 440},
+		{name: "FOK above the crossing depth is killed",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 4, Price: 110, TimeInForce: "FOK"},
+			wantStatus: "killed"},
+		{name: "FOK above the book depth is killed",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 6, Price: 120, TimeInForce: "FOK"},
+			wantStatus: "killed"},
+		{name: "market order sweeps within the slippage",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Kind: "market", Quantity: 3, MaxSlippageBps: 2_000},
+			wantStatus: "filled", wantFilled: 3, wantSpent: 320},
+		{name: "market order stops at the best price without slippage",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Kind: "market", Quantity: 3},
+			wantStatus: "cancelled", wantFilled: 2, wantSpent: 200},
+		{name: "market FOK beyond the slippage is killed",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Kind: "market", Quantity: 3, TimeInForce: "FOK", MaxSlippageBps: 1_000},
+			wantStatus: "killed"},
+		{name: "market order without an opposite book",
+			msg:      ptypes.PlaceOrderMsg{OrderType: "sell", Kind: "market", Quantity: 1},
+			wantCode: TxCodeNoLiquidity},
+		{name: "post-only below the best ask rests",
+			msg:        ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 1, Price: 99, PostOnly: true},
+			wantStatus: "active"},
+		{name: "post-only at the best ask is rejected",
+			msg:      ptypes.PlaceOrderMsg{OrderType: "buy", Quantity: 1, Price: 100, PostOnly: true},
+			wantCode: TxCodePostOnlyCrosses},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, id := orderTypeChain(t)
+			msg := tt.msg
+			msg.PoliticianID, msg.Currency = id, "USDT"
+			userID := "buyer"
+			if msg.OrderType == "sell" {
+				userID = "other"
+			}
+			result := chain.exec(placeOrder(userID, msg))[0]
+			if result.Code != tt.wantCode {
+				t.Fatalf("code = %d (%s), want %d", result.Code, result.Log, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				return
+			}
+			order := chain.app.orders[string(result.Data)]
+			if order.Status != tt.wantStatus || order.FilledQuantity != tt.wantFilled {
+				t.Fatalf("status %s filled %d, want %s %d", order.Status, order.FilledQuantity, tt.wantStatus, tt.wantFilled)
+			}
+			buyer := chain.app.accounts["buyer"]
+			if spent := 10_000 - buyer.USDTBalance; spent != tt.wantSpent {
+				t.Errorf("buyer spent %d USDT, want %d", spent, tt.wantSpent)
+			}
+			// 오더북에 남는 주문만 잔액을 동결합니다.
+			wantFrozen := int64(0)
+			if isOpenOrder(order) {
+				wantFrozen = remainingQuantity(order) * order.Price
+			}
+			if frozen := buyer.EscrowAccount.FrozenUSDTBalance; frozen != wantFrozen {
+				t.Errorf("buyer frozen USDT = %d, want %d", frozen, wantFrozen)
+			}
+			assertIndexMatchesScan(t, chain.app, tt.name)
+		})
+	}
+}
+
+// FOK 주문은 결제할 수 없는 maker를 체결 가능 수량에 세지 않으므로, 그런 maker가 있어도 부분 체결되지 않습니다.
+func TestFOKSkipsMakersThatCannotSettle(t *testing.T) {
+	tests := []struct {
+		name       string
+		quantity   int64
+		wantStatus string
+		wantFilled int64
+	}{
+		// 100의 maker를 세면 4개를 채울 수 있어 보이지만, 실제로는 120의 3개만 체결됩니다.
+		{name: "not enough without the broken maker", quantity: 4, wantStatus: "killed"},
+		{name: "enough without the broken maker", quantity: 3, wantStatus: "filled", wantFilled: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, id := orderTypeChain(t)
+			// 100의 매도 주문은 코인이 동결되어 있지 않고, seller는 다른 주문에 동결된 코인밖에 없어 결제할 수 없습니다.
+			seller := chain.app.accounts["seller"]
+			seller.PoliticianCoins[string(id)] = 3
+			seller.EscrowAccount.FrozenPoliticianCoins[string(id)] = 3
+			var broken string
+			for _, orderID := range seller.EscrowAccount.ActiveOrders {
+				if chain.app.orders[orderID].Price == 100 {
+					broken = orderID
+				}
+			}
+			removeActiveOrder(seller, broken)
+			chain.app.touchAccount("seller")
+
+			result := chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: tt.quantity, Price: 120, TimeInForce: "FOK"}))[0]
+			order := chain.app.orders[string(result.Data)]
+			if order.Status != tt.wantStatus || order.FilledQuantity != tt.wantFilled {
+				t.Fatalf("status %s filled %d, want %s %d", order.Status, order.FilledQuantity, tt.wantStatus, tt.wantFilled)
+			}
+			if spent := 10_000 - chain.app.accounts["buyer"].USDTBalance; spent != tt.wantFilled*120 {
+				t.Errorf("buyer spent %d USDT, want %d", spent, tt.wantFilled*120)
+			}
+		})
+	}
+}
+
+// 주문을 접수하면 미체결 부분만큼 한 번 동결되고, 취소나 만료로 닫히면 남은 동결이 해제되며,
+// 이미 해제된 주문을 다시 해제하거나 동결된 잔액을 다른 주문에 또 쓸 수 없습니다.
+func TestOrderEscrowLifecycle(t *testing.T) {
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	politician.RemainingCoins -= 5
+	politician.DistributedCoins = 5
+	id := politician.ID
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"buyer":  {Address: "buyer", USDTBalance: 1_000},
+			"seller": {Address: "seller", PoliticianCoins: map[string]int64{string(id): 5}},
+		},
+	})
+	// check는 계정의 동결 잔액과 에스크로가 잡힌 주문 목록을 확인합니다. 잔액 자체는 동결로 줄지 않습니다.
+	check := func(step, userID string, wantFrozen int64, wantOrders ...string) {
+		t.Helper()
+		account := chain.app.accounts[userID]
+		frozen := account.EscrowAccount.FrozenUSDTBalance
+		if userID == "seller" {
+			frozen = account.EscrowAccount.FrozenPoliticianCoins[string(id)]
+		}
+		if frozen != wantFrozen {
+			t.Fatalf("%s: %s frozen = %d, want %d", step, userID, frozen, wantFrozen)
+		}
+		if got := account.EscrowAccount.ActiveOrders; fmt.Sprint(got) != fmt.Sprint(wantOrders) {
+			t.Fatalf("%s: %s escrow orders = %v, want %v", step, userID, got, wantOrders)
+		}
+	}
+	cancel := func(userID, orderID string) ptypes.TxData {
+		return ptypes.TxData{Action: "cancel_order", UserID: userID, Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: orderID}}}
+	}
+	release := func(userID, orderID string) ptypes.TxData {
+		return ptypes.TxData{Action: "release_escrow", UserID: userID, Msg: &ptypes.TxMsg{ReleaseEscrow: &ptypes.ReleaseEscrowMsg{OrderID: orderID}}}
+	}
+
+	// 접수: 매수는 수량 × 가격의 USDT, 매도는 코인 수량을 동결합니다.
+	buy := string(chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 6, Price: 100}))[0].Data)
+	sell := string(chain.block(placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 3, Price: 200,
+		ExpiresHeight: chain.height + 3}))[0].Data)
+	check("after placing", "buyer", 600, buy)
+	check("after placing", "seller", 3, sell)
+	if got := chain.app.orders[buy].EscrowAmount; got != 600 {
+		t.Fatalf("buy order escrow amount = %d, want 600", got)
+	}
+	if balance := chain.app.accounts["buyer"].USDTBalance; balance != 1_000 {
+		t.Fatalf("buyer balance after placing = %d, want 1000", balance)
+	}
+
+	// 동결된 잔액은 다른 주문이나 출금에 다시 쓸 수 없습니다.
+	results := chain.exec(
+		placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 5, Price: 100}),
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 3, Price: 300}),
+		ptypes.TxData{Action: "withdraw_stablecoin", UserID: "buyer", Msg: &ptypes.TxMsg{Withdraw: &ptypes.WithdrawMsg{Token: "USDT", Amount: 401, ToAddress: "0xabc"}}},
+	)
+	for i, r := range results {
+		if r.Code != TxCodeInsufficientFunds {
+			t.Fatalf("tx %d spending frozen funds: code %d (%s), want %d", i, r.Code, r.Log, TxCodeInsufficientFunds)
+		}
+	}
+	check("after overspending", "buyer", 600, buy)
+	check("after overspending", "seller", 3, sell)
+
+	// 만료: 만료 높이의 블록 끝에서 남은 코인 동결이 해제됩니다.
+	chain.block()
+	if status := chain.app.orders[sell].Status; status != "expired" {
+		t.Fatalf("sell order status = %s, want expired", status)
+	}
+	check("after expiry", "seller", 0)
+
+	// 취소: 남은 USDT 동결이 해제되고 잔액은 그대로입니다.
+	chain.block(cancel("buyer", buy))
+	check("after cancelling", "buyer", 0)
+	if balance := chain.app.accounts["buyer"].USDTBalance; balance != 1_000 {
+		t.Fatalf("buyer balance after cancelling = %d, want 1000", balance)
+	}
+
+	// 이미 해제된 주문은 다시 해제해도 아무것도 바뀌지 않고, 다시 취소할 수 없습니다.
+	chain.block(release("buyer", buy), release("seller", sell))
+	check("after releasing again", "buyer", 0)
+	check("after releasing again", "seller", 0)
+	if r := chain.exec(cancel("buyer", buy))[0]; r.Code != TxCodeOrderClosed {
+		t.Fatalf("cancelling a cancelled order: code %d, want %d", r.Code, TxCodeOrderClosed)
+	}
+
+	// 해제된 잔액으로 새 주문을 낼 수 있습니다.
+	again := string(chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 10, Price: 100}))[0].Data)
+	check("after placing again", "buyer", 1_000, again)
+}
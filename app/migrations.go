@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// stateMigration은 한 버전의 AppState를 다음 버전으로 변환합니다.
+type stateMigration func(state *ptypes.AppState) error
+
+// stateMigrations는 "이 버전에서 다음 버전으로" 가는 마이그레이션 목록입니다.
+// 버전 필드가 없던 이전 노드의 상태 블롭은 버전 0으로 읽히므로 1번 스키마와 동일하게 취급합니다.
+var stateMigrations = map[int]stateMigration{
+	0: migrateV1ToV2,
+	1: migrateV1ToV2,
+}
+
+// migrateState는 저장된 상태를 currentStateVersion까지 순서대로 마이그레이션합니다.
+func migrateState(state *ptypes.AppState) error {
+	if state.Version > currentStateVersion {
+		return fmt.Errorf("state version %d is newer than supported version %d", state.Version, currentStateVersion)
+	}
+	for state.Version < currentStateVersion {
+		migrate, ok := stateMigrations[state.Version]
+		if !ok {
+			return fmt.Errorf("no migration registered for state version %d", state.Version)
+		}
+		if err := migrate(state); err != nil {
+			return fmt.Errorf("migration from version %d failed: %w", state.Version, err)
+		}
+	}
+	ensureStateMaps(state)
+	return nil
+}
+
+// migrateV1ToV2는 이전 노드가 JSON 블롭 하나로 저장한 상태를 현재 스키마로 바꿉니다.
+//   - 이름으로 저장되던 정치인에 이름과 지역으로 만든 정식 ID를 부여하고, 정치인과 계정의 코인·지지 목록을 ID로 다시 키잉합니다.
+//   - 거버넌스 파라미터를 기본값으로 추가합니다. 이전에는 찬성 한 표로 즉시 가결되고 가결된 제안은 지웠으므로,
+//     남아 있는 제안은 모두 지금부터 투표 기간이 시작되는 계정당 한 표 방식의 등록 제안입니다.
+//   - 이전 상태에는 주문 장부가 없어 동결된 잔액에 대응하는 미체결 주문이 없으므로, 에스크로를 모두 해제합니다.
+//
+// 키별 저장은 마이그레이션한 상태를 읽은 loadLegacyState가 다음 Commit에서 수행합니다.
+func migrateV1ToV2(state *ptypes.AppState) error {
+	ensureStateMaps(state)
+	if err := assignPoliticianIDs(state); err != nil {
+		return err
+	}
+
+	if state.GovParams == nil {
+		params := ptypes.DefaultGovParams()
+		state.GovParams = &params
+	}
+	for _, proposal := range state.Proposals {
+		proposal.Type = ptypes.ProposalTypeRegister
+		proposal.Status = ptypes.ProposalStatusVoting
+		proposal.TallyMode = ptypes.TallyModeOneAccountOneVote
+		proposal.SubmitHeight = state.Height
+		proposal.VotingEndHeight = state.Height + state.GovParams.VotingPeriod
+	}
+
+	for _, account := range state.Accounts {
+		account.EscrowAccount = ptypes.EscrowAccount{UserID: account.EscrowAccount.UserID}
+		ensureEscrowAccount(account)
+	}
+	state.Version = 2
+	return nil
+}
+
+// assignPoliticianIDs는 ID가 없는 정치인과 제안 대상에 이름과 지역으로 정식 ID를 부여하고, 정치인을 ID로 다시 키잉합니다.
+func assignPoliticianIDs(state *ptypes.AppState) error {
+	err := rekeyPoliticians(state, func(p *ptypes.Politician) string {
+		if p.ID == "" {
+			p.ID = ptypes.NewPoliticianID(p.Name, p.Region)
+		}
+		return string(p.ID)
+	})
+	if err != nil {
+		return err
+	}
+	for _, proposal := range state.Proposals {
+		if proposal.Politician.ID == "" {
+			proposal.Politician.ID = ptypes.NewPoliticianID(proposal.Politician.Name, proposal.Politician.Region)
+		}
+	}
+	return nil
+}
+
+// rekeyPoliticians는 정치인 맵의 키를 keyOf가 반환하는 값으로 바꾸고,
+// 계정의 코인 잔액, 받은 코인, 지지 목록과 이전 주문 목록에 남은 이전 키도 새 키로 바꿉니다.
+// 에스크로는 migrateV1ToV2가 모두 해제하므로 바꾸지 않습니다.
+func rekeyPoliticians(state *ptypes.AppState, keyOf func(*ptypes.Politician) string) error {
+	ensureStateMaps(state)
+	renamed := make(map[string]string)
+	politicians := make(map[string]*ptypes.Politician, len(state.Politicians))
+	for id, politician := range state.Politicians {
+		if politician.Name == "" {
+			return fmt.Errorf("politician %q has no name", id)
+		}
+		key := keyOf(politician)
+		if _, exists := politicians[key]; exists {
+			return fmt.Errorf("politician %q and another entry map to the same key %q", id, key)
+		}
+		politicians[key] = politician
+		if id != key {
+			renamed[id] = key
+		}
+	}
+	state.Politicians = politicians
+	if len(renamed) == 0 {
+		return nil
+	}
+
+	rename := func(id string) string {
+		if key, ok := renamed[id]; ok {
+			return key
+		}
+		return id
+	}
+	renameID := func(id ptypes.PoliticianID) ptypes.PoliticianID {
+		return ptypes.PoliticianID(rename(string(id)))
+	}
+	for _, account := range state.Accounts {
+		account.PoliticianCoins = renameCoinKeys(account.PoliticianCoins, rename)
+		if account.ReceivedCoins != nil {
+			received := make(map[string]bool, len(account.ReceivedCoins))
+			for id, ok := range account.ReceivedCoins {
+				received[rename(id)] = received[rename(id)] || ok
+			}
+			account.ReceivedCoins = received
+		}
+		for i, id := range account.Politicians {
+			account.Politicians[i] = rename(id)
+		}
+		for i := range account.ActiveOrders {
+			account.ActiveOrders[i].PoliticianID = renameID(account.ActiveOrders[i].PoliticianID)
+		}
+	}
+	return nil
+}
+
+// renameCoinKeys는 정치인 ID를 키로 하는 코인 수량 맵의 키를 바꿉니다. 같은 키로 모이는 수량은 더합니다.
+func renameCoinKeys(coins map[string]int64, rename func(string) string) map[string]int64 {
+	if coins == nil {
+		return nil
+	}
+	renamed := make(map[string]int64, len(coins))
+	for id, amount := range coins {
+		renamed[rename(id)] += amount
+	}
+	return renamed
+}
+
+// ensureStateMaps는 JSON에서 null로 읽힌 맵들을 빈 맵으로 초기화합니다.
+func ensureStateMaps(state *ptypes.AppState) {
+	if state.Accounts == nil {
+		state.Accounts = make(map[string]*ptypes.Account)
+	}
+	if state.Proposals == nil {
+		state.Proposals = make(map[string]*ptypes.Proposal)
+	}
+	if state.Politicians == nil {
+		state.Politicians = make(map[string]*ptypes.Politician)
+	}
+	if state.Orders == nil {
+		state.Orders = make(map[string]*ptypes.TradeOrder)
+	}
+	if state.Trades == nil {
+		state.Trades = make(map[string]*ptypes.Trade)
+	}
+}
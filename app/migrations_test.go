@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// baselineState는 키별 저장 이전 노드가 stateKey에 저장하던 JSON 블롭입니다.
+// 버전 필드가 없고, 정치인과 코인은 이름으로 키잉되며, 주문 장부 없이 동결된 잔액이 남아 있습니다.
+const baselineState = `{
+	"height": 7,
+	"app_hash": "AQID",
+	"accounts": {
+		"alice": {
+			"address": "alice",
+			"politicians": ["홍길동"],
+			"politician_coins": {"홍길동": 3},
+			"received_coins": {"홍길동": true},
+			"usdt_balance": 500,
+			"escrow_account": {"user_id": "alice", "frozen_usdt_balance": 200, "frozen_politician_coins": {"홍길동": 1}, "active_orders": ["lost-order"]}
+		}
+	},
+	"proposals": {
+		"prop-1": {"id": "prop-1", "politician": {"name": "김철수", "region": "부산", "party": "무소속"}, "proposer": "alice", "votes": {}}
+	},
+	"politicians": {
+		"홍길동": {"name": "홍길동", "region": "서울", "party": "무소속", "supporters": ["alice"], "total_coin_supply": 100, "remaining_coins": 97, "distributed_coins": 3}
+	}
+}`
+
+// 기존 노드의 상태 블롭은 불러올 때 현재 스키마로 마이그레이션되고, 다음 Commit에서 키별로 다시 저장됩니다.
+func TestLoadBaselineStateBlob(t *testing.T) {
+	db := dbm.NewMemDB()
+	if err := db.SetSync(legacyStateKey, []byte(baselineState)); err != nil {
+		t.Fatal(err)
+	}
+	app := NewPoliticianApp(db, log.NewNopLogger())
+
+	id := ptypes.NewPoliticianID("홍길동", "서울")
+	if app.height != 7 || len(app.politicians) != 1 || app.politicians[string(id)] == nil {
+		t.Fatalf("height %d politicians %v, want 7 and one keyed by %s", app.height, app.politicians, id)
+	}
+	if p := app.politicians[string(id)]; p.ID != id || p.RemainingCoins != 97 {
+		t.Errorf("politician = %+v", p)
+	}
+	alice := app.accounts["alice"]
+	if alice.PoliticianCoins[string(id)] != 3 || !alice.ReceivedCoins[string(id)] || len(alice.Politicians) != 1 || alice.Politicians[0] != string(id) {
+		t.Errorf("alice coins %v received %v politicians %v, want them keyed by %s", alice.PoliticianCoins, alice.ReceivedCoins, alice.Politicians, id)
+	}
+	// 대응하는 주문이 없는 동결 잔액은 해제되고, 잔액은 그대로입니다.
+	if escrow := alice.EscrowAccount; escrow.UserID != "alice" || escrow.FrozenUSDTBalance != 0 || len(escrow.FrozenPoliticianCoins) != 0 || len(escrow.ActiveOrders) != 0 {
+		t.Errorf("escrow = %+v, want released", escrow)
+	}
+	if alice.USDTBalance != 500 {
+		t.Errorf("USDT balance = %d, want 500", alice.USDTBalance)
+	}
+	if app.govParams != ptypes.DefaultGovParams() {
+		t.Errorf("gov params = %+v, want defaults", app.govParams)
+	}
+	proposal := app.proposals["prop-1"]
+	if proposal.Type != ptypes.ProposalTypeRegister || proposal.Status != ptypes.ProposalStatusVoting || proposal.TallyMode != ptypes.TallyModeOneAccountOneVote {
+		t.Errorf("proposal type %q status %q tally %q", proposal.Type, proposal.Status, proposal.TallyMode)
+	}
+	if proposal.SubmitHeight != 7 || proposal.VotingEndHeight != 7+app.govParams.VotingPeriod {
+		t.Errorf("proposal submitted at %d ends at %d", proposal.SubmitHeight, proposal.VotingEndHeight)
+	}
+	if proposal.Politician.ID != ptypes.NewPoliticianID("김철수", "부산") {
+		t.Errorf("proposal politician ID = %s", proposal.Politician.ID)
+	}
+
+	if _, err := app.FinalizeBlock(context.Background(), &types.RequestFinalizeBlock{Height: 8}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Commit(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if v := storedStateVersion(t, db); v != currentStateVersion {
+		t.Fatalf("stored state version = %d, want %d", v, currentStateVersion)
+	}
+	if bz, err := db.Get(legacyStateKey); err != nil || bz != nil {
+		t.Fatalf("legacy blob after commit = %q, %v; want it removed", bz, err)
+	}
+	reloaded := NewPoliticianApp(db, log.NewNopLogger())
+	if reloaded.height != 8 || reloaded.accounts["alice"].PoliticianCoins[string(id)] != 3 || reloaded.proposals["prop-1"].Status != ptypes.ProposalStatusVoting {
+		t.Fatalf("reloaded height %d state %+v", reloaded.height, reloaded.accounts["alice"])
+	}
+}
+
+// 이 노드보다 새 버전의 상태는 마이그레이션하지 않고 거부합니다.
+func TestMigrateStateRejectsNewerVersion(t *testing.T) {
+	if err := migrateState(&ptypes.AppState{Version: currentStateVersion + 1}); err == nil {
+		t.Fatal("migrateState accepted a newer state version")
+	}
+}
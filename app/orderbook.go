@@ -0,0 +1,88 @@
+package app
+
+import (
+	"sort"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// bookKey는 오더북 한쪽(정치인, 매수·매도, 결제 통화)을 가리킵니다.
+type bookKey struct {
+	politicianID ptypes.PoliticianID
+	side         string // "buy" 또는 "sell"
+	currency     string
+}
+
+// orderBookIndex는 미체결 주문을 오더북별로, 만료가 지정된 미체결 주문을 따로 모은 색인입니다.
+// 주문 자체는 app.orders에 있고, 색인은 상태에서 언제든 다시 만들 수 있으므로 저장하지 않습니다.
+// 매칭과 만료 정리는 지금까지 접수된 모든 주문 대신 이 색인만 살펴봅니다.
+type orderBookIndex struct {
+	books    map[bookKey]map[string]*ptypes.TradeOrder
+	expiring map[string]*ptypes.TradeOrder
+}
+
+func orderBookKey(order *ptypes.TradeOrder) bookKey {
+	return bookKey{politicianID: order.PoliticianID, side: order.OrderType, currency: order.Currency}
+}
+
+// reindexOrders는 app.orders 전체로 미체결 주문 색인을 다시 만듭니다.
+// 상태를 불러오거나 제네시스, 스냅샷, 마이그레이션으로 주문 맵을 교체한 뒤에 호출합니다.
+func (app *PoliticianApp) reindexOrders() {
+	app.orderBooks = orderBookIndex{
+		books:    make(map[bookKey]map[string]*ptypes.TradeOrder),
+		expiring: make(map[string]*ptypes.TradeOrder),
+	}
+	for _, order := range app.orders {
+		app.indexOrder(order)
+	}
+}
+
+// indexOrder는 주문의 현재 상태에 맞게 색인을 고칩니다. 미체결이면 넣고, 종료되었으면 뺍니다.
+// 주문을 접수하거나 체결, 취소, 만료로 주문 상태를 바꾼 코드는 반드시 호출해야 합니다.
+func (app *PoliticianApp) indexOrder(order *ptypes.TradeOrder) {
+	key := orderBookKey(order)
+	if !isOpenOrder(order) {
+		if book := app.orderBooks.books[key]; book != nil {
+			delete(book, order.ID)
+			if len(book) == 0 {
+				delete(app.orderBooks.books, key)
+			}
+		}
+		delete(app.orderBooks.expiring, order.ID)
+		return
+	}
+	book := app.orderBooks.books[key]
+	if book == nil {
+		book = make(map[string]*ptypes.TradeOrder)
+		app.orderBooks.books[key] = book
+	}
+	book[order.ID] = order
+	if order.ExpiresHeight != 0 || order.ExpiresAt != 0 {
+		app.orderBooks.expiring[order.ID] = order
+	}
+}
+
+// openOrdersFor는 한 정치인의 모든 오더북에 있는 미체결 주문을 접수 순서대로 반환합니다.
+func (app *PoliticianApp) openOrdersFor(politicianID ptypes.PoliticianID) []*ptypes.TradeOrder {
+	var open []*ptypes.TradeOrder
+	for key, book := range app.orderBooks.books {
+		if key.politicianID != politicianID {
+			continue
+		}
+		for _, order := range book {
+			open = append(open, order)
+		}
+	}
+	sortBySequence(open)
+	return open
+}
+
+// sortBySequence는 주문을 접수 순서(같으면 ID 순)로 정렬합니다.
+func sortBySequence(orders []*ptypes.TradeOrder) {
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Sequence != orders[j].Sequence {
+			return orders[i].Sequence < orders[j].Sequence
+		}
+		return orders[i].ID < orders[j].ID
+	})
+}
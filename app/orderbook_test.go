@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// testChainID는 테스트 체인의 체인 ID입니다.
+const testChainID = "test-chain"
+
+// testRegistrarKey는 테스트 체인의 등록 키(AuthParams.KeyRegistrar)입니다.
+var testRegistrarKey = ed25519.GenPrivKeyFromSecret([]byte("test-key-registrar"))
+
+// testUserKey는 테스트 체인에서 계정 ID로 파생하는 사용자 서명키입니다.
+func testUserKey(userID string) ed25519.PrivKey {
+	return ed25519.GenPrivKeyFromSecret([]byte(userID))
+}
+
+// testChain은 제네시스부터 블록을 실행하는 테스트용 체인입니다. 계정의 서명키는 계정 ID에서 파생하고,
+// 등록 키가 서명하는 액션은 testRegistrarKey로 서명합니다.
+type testChain struct {
+	t      *testing.T
+	db     dbm.DB
+	app    *PoliticianApp
+	height int64
+	nonces map[string]uint64
+}
+
+func newTestChain(t *testing.T, gs ptypes.GenesisState) *testChain {
+	t.Helper()
+	for id, account := range gs.Accounts {
+		account.PubKey = testUserKey(id).PubKey().Bytes()
+	}
+	if gs.AuthParams == nil {
+		gs.AuthParams = &ptypes.AuthParams{KeyRegistrar: testRegistrarKey.PubKey().Bytes()}
+	}
+	genesis, err := json.Marshal(gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := dbm.NewMemDB()
+	app := NewPoliticianApp(db, log.NewNopLogger())
+	if _, err := app.InitChain(context.Background(), &types.RequestInitChain{ChainId: testChainID, AppStateBytes: genesis}); err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{t: t, db: db, app: app, nonces: make(map[string]uint64)}
+}
+
+func (c *testChain) sign(tx ptypes.TxData) []byte {
+	key := testUserKey(tx.UserID)
+	if ptypes.IsRegistrarAction(tx.Action) {
+		key = testRegistrarKey
+	}
+	c.nonces[tx.UserID]++
+	signed, err := ptypes.SignTx(key, testChainID, c.nonces[tx.UserID], &tx)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return ptypes.MarshalSignedTx(signed)
+}
+
+// blockTime은 테스트 체인에서 height 블록의 시간(Unix 초)입니다.
+func blockTime(height int64) int64 { return 1_700_000_000 + height }
+
+// exec는 트랜잭션을 담은 블록 하나를 실행하고 커밋한 뒤 트랜잭션 결과를 반환합니다.
+func (c *testChain) exec(txs ...ptypes.TxData) []*types.ExecTxResult {
+	c.t.Helper()
+	raw := make([][]byte, len(txs))
+	for i, tx := range txs {
+		raw[i] = c.sign(tx)
+	}
+	return c.execRaw(raw...)
+}
+
+// execRaw는 이미 서명된 트랜잭션을 담은 블록 하나를 실행하고 커밋한 뒤 트랜잭션 결과를 반환합니다.
+func (c *testChain) execRaw(raw ...[]byte) []*types.ExecTxResult {
+	c.t.Helper()
+	c.height++
+	res, err := c.app.FinalizeBlock(context.Background(), &types.RequestFinalizeBlock{Height: c.height, Time: time.Unix(blockTime(c.height), 0), Txs: raw})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := c.app.Commit(context.Background(), nil); err != nil {
+		c.t.Fatal(err)
+	}
+	return res.TxResults
+}
+
+// block은 exec와 같지만 모든 트랜잭션이 성공했는지 확인합니다.
+func (c *testChain) block(txs ...ptypes.TxData) []*types.ExecTxResult {
+	c.t.Helper()
+	results := c.exec(txs...)
+	for i, r := range results {
+		if r.Code != types.CodeTypeOK {
+			c.t.Fatalf("block %d tx %d (%s): code %d %s", c.height, i, txs[i].Action, r.Code, r.Log)
+		}
+	}
+	return results
+}
+
+func placeOrder(userID string, msg ptypes.PlaceOrderMsg) ptypes.TxData {
+	return ptypes.TxData{Action: "place_order", UserID: userID, Msg: &ptypes.TxMsg{PlaceOrder: &msg}}
+}
+
+// scannedBooks는 모든 주문을 훑어 색인에 있어야 할 미체결 주문 ID를 오더북별로 모읍니다.
+func scannedBooks(app *PoliticianApp) (map[bookKey][]string, []string) {
+	books := make(map[bookKey][]string)
+	var expiring []string
+	for id, order := range app.orders {
+		if !isOpenOrder(order) {
+			continue
+		}
+		books[orderBookKey(order)] = append(books[orderBookKey(order)], id)
+		if order.ExpiresHeight != 0 || order.ExpiresAt != 0 {
+			expiring = append(expiring, id)
+		}
+	}
+	return books, expiring
+}
+
+func indexedBooks(app *PoliticianApp) (map[bookKey][]string, []string) {
+	books := make(map[bookKey][]string)
+	for key, book := range app.orderBooks.books {
+		for id := range book {
+			books[key] = append(books[key], id)
+		}
+	}
+	var expiring []string
+	for id := range app.orderBooks.expiring {
+		expiring = append(expiring, id)
+	}
+	return books, expiring
+}
+
+func assertIndexMatchesScan(t *testing.T, app *PoliticianApp, step string) {
+	t.Helper()
+	wantBooks, wantExpiring := scannedBooks(app)
+	gotBooks, gotExpiring := indexedBooks(app)
+	normalize := func(books map[bookKey][]string, expiring []string) (map[bookKey][]string, []string) {
+		for _, ids := range books {
+			slices.Sort(ids)
+		}
+		slices.Sort(expiring)
+		return books, expiring
+	}
+	wantBooks, wantExpiring = normalize(wantBooks, wantExpiring)
+	gotBooks, gotExpiring = normalize(gotBooks, gotExpiring)
+	if len(gotBooks) != len(wantBooks) {
+		t.Fatalf("%s: %d books indexed, want %d (%v vs %v)", step, len(gotBooks), len(wantBooks), gotBooks, wantBooks)
+	}
+	for key, want := range wantBooks {
+		if !slices.Equal(gotBooks[key], want) {
+			t.Fatalf("%s: book %+v = %v, want %v", step, key, gotBooks[key], want)
+		}
+	}
+	if !slices.Equal(gotExpiring, wantExpiring) {
+		t.Fatalf("%s: expiring = %v, want %v", step, gotExpiring, wantExpiring)
+	}
+}
+
+// 미체결 주문 색인은 접수, 부분·전량 체결, 취소, 만료 뒤에도, 그리고 상태를 다시 불러온 뒤에도 전체 스캔과 같아야 합니다.
+func TestOrderBookIndexTracksOpenOrders(t *testing.T) {
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	politician.RemainingCoins -= 1000
+	politician.DistributedCoins = 1000
+	id := politician.ID
+	account := func(userID string, coins, usdt, usdc int64) *ptypes.Account {
+		return &ptypes.Account{Address: userID, PoliticianCoins: map[string]int64{string(id): coins}, USDTBalance: usdt, USDCBalance: usdc}
+	}
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"buyer":  account("buyer", 0, 100_000, 100_000),
+			"seller": account("seller", 1000, 0, 0),
+		},
+	})
+
+	results := chain.block(
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 10, Price: 100}),
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDC", Quantity: 10, Price: 100}),
+		placeOrder("seller", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 5, Price: 120, ExpiresHeight: 3}),
+		placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 5, Price: 90}),
+	)
+	usdtAsk, usdcAsk, expiringAsk := string(results[0].Data), string(results[1].Data), string(results[2].Data)
+	assertIndexMatchesScan(t, chain.app, "after placing")
+	if n := len(chain.app.orderBooks.books); n != 3 {
+		t.Fatalf("books = %d, want 3 (USDT asks, USDC asks, USDT bids)", n)
+	}
+
+	chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDT", Quantity: 4, Price: 100}))
+	if status := chain.app.orders[usdtAsk].Status; status != "partial" {
+		t.Fatalf("USDT ask status = %s, want partial", status)
+	}
+	assertIndexMatchesScan(t, chain.app, "after a partial fill")
+
+	chain.block(
+		placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "buy", Currency: "USDC", Quantity: 10, Price: 100}),
+		ptypes.TxData{Action: "cancel_order", UserID: "seller", Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: usdtAsk}}},
+	)
+	if status := chain.app.orders[usdcAsk].Status; status != "filled" {
+		t.Fatalf("USDC ask status = %s, want filled", status)
+	}
+	if status := chain.app.orders[expiringAsk].Status; status != "expired" {
+		t.Fatalf("expiring ask status = %s, want expired", status)
+	}
+	assertIndexMatchesScan(t, chain.app, "after fill, cancel and expiry")
+	if n := len(chain.app.orderBooks.books); n != 1 {
+		t.Fatalf("books = %d, want only the USDT bids", n)
+	}
+
+	reloaded := NewPoliticianApp(chain.db, log.NewNopLogger())
+	assertIndexMatchesScan(t, reloaded, "after reload")
+	if n := len(reloaded.orderBooks.books); n != 1 {
+		t.Fatalf("reloaded books = %d, want 1", n)
+	}
+}
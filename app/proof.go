@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cometbft/cometbft/abci/types"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"github.com/jclee286/politisian/pkg/smt"
+)
+
+// provableQueries는 RequestQuery.Prove를 지원하는 쿼리 경로에서 증명할 상태 키를 구합니다.
+// 증명을 요청하면 응답 Value는 JSON이 아니라 마지막 커밋 시점에 저장된 protobuf 값 그대로이며
+// (ptypes.UnmarshalAccount 등으로 해석), Key에는 상태 키가 담깁니다.
+// 잔액은 계정 값에 포함되므로 계정 증명으로 함께 검증됩니다.
+var provableQueries = map[string]func(params url.Values) (string, error){
+	"/account":    provableParam("address", accountPrefix),
+	"/order":      provableParam("id", orderPrefix),
+	"/politician": provableParam("id", politicianPrefix),
+	"/store":      storeKeyParam,
+}
+
+func provableParam(name, prefix string) func(url.Values) (string, error) {
+	return func(params url.Values) (string, error) {
+		value := params.Get(name)
+		if value == "" {
+			return "", fmt.Errorf("%s parameter required", name)
+		}
+		return prefix + value, nil
+	}
+}
+
+// storeKeyParam은 /store 쿼리의 key 파라미터가 알려진 상태 키인지 확인합니다.
+func storeKeyParam(params url.Values) (string, error) {
+	key := params.Get("key")
+	if !isStateKey(key) {
+		return "", fmt.Errorf("key parameter must be a state key such as %s<address>", accountPrefix)
+	}
+	return key, nil
+}
+
+// isStateKey는 key가 트리에 들어가는 상태 키인지 확인합니다.
+func isStateKey(key string) bool {
+	if key == orderSequenceKey || key == govParamsKey || key == authParamsKey {
+		return true
+	}
+	for _, prefix := range []string{accountPrefix, politicianPrefix, proposalPrefix, orderPrefix, tradePrefix} {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryStore는 마지막 커밋 시점의 상태 키 값을 그대로 반환합니다.
+func queryStore(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	key, err := storeKeyParam(params)
+	if err != nil {
+		return queryError(QueryCodeInvalidParam, err.Error())
+	}
+	value, err := app.db.Get([]byte(key))
+	if err != nil {
+		return queryError(QueryCodeInternal, "failed to read state")
+	}
+	if value == nil {
+		return queryError(QueryCodeNotFound, "key not found")
+	}
+	return &types.ResponseQuery{Code: QueryCodeOK, Key: []byte(key), Value: value}
+}
+
+// queryWithProof는 마지막 커밋의 값과 그 값이 앱 해시에 포함(또는 미포함)됨을 보이는 증명을 반환합니다.
+// 증명은 높이 Height의 상태에 대한 것이며, 라이트 클라이언트는 Height+1 블록 헤더의 AppHash로 검증합니다.
+// 키가 없으면 Code는 OK, Value는 비어 있고 비포함 증명이 담깁니다.
+func (app *PoliticianApp) queryWithProof(path string, params url.Values) *types.ResponseQuery {
+	keyFor, ok := provableQueries[path]
+	if !ok {
+		return queryError(QueryCodeInvalidParam, "proofs are not supported for this query path")
+	}
+	key, err := keyFor(params)
+	if err != nil {
+		return queryError(QueryCodeInvalidParam, err.Error())
+	}
+	if app.committedTree == nil {
+		return queryError(QueryCodeInternal, "state proofs are not available until the next commit")
+	}
+	value, err := app.db.Get([]byte(key))
+	if err != nil {
+		return queryError(QueryCodeInternal, "failed to read state")
+	}
+	op := smt.NewProofOp([]byte(key), app.committedTree.Prove([]byte(key))).ProofOp()
+	return &types.ResponseQuery{
+		Code:     QueryCodeOK,
+		Key:      []byte(key),
+		Value:    value,
+		ProofOps: &cmtcrypto.ProofOps{Ops: []cmtcrypto.ProofOp{op}},
+	}
+}
@@ -0,0 +1,291 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/cometbft/cometbft/abci/types"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 쿼리 응답 코드입니다. 모든 쿼리 경로가 같은 코드 체계를 사용합니다.
+const (
+	QueryCodeOK           uint32 = 0
+	QueryCodeUnknownPath  uint32 = 1 // 등록되지 않은 쿼리 경로
+	QueryCodeInvalidParam uint32 = 2 // 필수 파라미터 누락 또는 형식 오류
+	QueryCodeNotFound     uint32 = 3 // 대상이 존재하지 않음
+	QueryCodeInternal     uint32 = 4 // 직렬화 등 내부 오류
+)
+
+const (
+	queryCodespace = "politisian"
+
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// queryHandler는 파싱된 URL 파라미터로 하나의 쿼리 경로를 처리합니다.
+type queryHandler func(app *PoliticianApp, params url.Values) *types.ResponseQuery
+
+// queryRoutes는 쿼리 경로와 핸들러를 연결합니다.
+var queryRoutes = map[string]queryHandler{
+	"/github.com/jclee286/politisian/list": queryPoliticians,
+	"/politicians":                         queryPoliticians,
+	"/proposals/list":                      queryProposals,
+	"/account":                             queryAccount,
+	"/user":                                queryUser,
+	"/politician":                          queryPolitician,
+	"/order":                               queryOrder,
+	"/orders":                              queryOrders,
+	"/user-orders":                         queryUserOrders,
+	"/store":                               queryStore,
+	"/params/governance":                   queryGovParams,
+	"/params/auth":                         queryAuthParams,
+}
+
+// routeQuery는 "/path?key=value" 형태의 쿼리 경로를 파싱하여 해당 핸들러로 전달합니다.
+func (app *PoliticianApp) routeQuery(req *types.RequestQuery) *types.ResponseQuery {
+	u, err := url.Parse(req.Path)
+	if err != nil {
+		return queryError(QueryCodeInvalidParam, fmt.Sprintf("invalid query path: %v", err))
+	}
+	handler, ok := queryRoutes[u.Path]
+	if !ok {
+		return queryError(QueryCodeUnknownPath, "unknown query path")
+	}
+	params, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return queryError(QueryCodeInvalidParam, fmt.Sprintf("invalid query parameters: %v", err))
+	}
+	var res *types.ResponseQuery
+	if req.Prove {
+		res = app.queryWithProof(u.Path, params)
+	} else {
+		res = handler(app, params)
+	}
+	res.Height = app.height
+	return res
+}
+
+// queryPoliticians는 ID를 키로 하는 정치인 목록을 ID 순으로 limit/offset만큼 반환합니다. name, region 파라미터로 거를 수 있습니다.
+// 상장 폐지된 정치인은 include_delisted=true일 때만 포함합니다.
+func queryPoliticians(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	limit, offset, errRes := parsePagination(params)
+	if errRes != nil {
+		return errRes
+	}
+	name, region := params.Get("name"), params.Get("region")
+	includeDelisted := params.Get("include_delisted") == "true"
+	var candidates []*ptypes.Politician
+	switch {
+	case name != "":
+		candidates = app.politiciansByName(name)
+	case region != "":
+		candidates = app.politiciansByRegion(region)
+	default:
+		for _, politician := range app.politicians {
+			candidates = append(candidates, politician)
+		}
+	}
+	var ids []string
+	for _, politician := range candidates {
+		if (region == "" || politician.Region == region) && (includeDelisted || !politician.Delisted) {
+			ids = append(ids, string(politician.ID))
+		}
+	}
+	sort.Strings(ids)
+	ids = paginate(ids, limit, offset)
+	politicians := make(map[string]*ptypes.Politician, len(ids))
+	for _, id := range ids {
+		politicians[id] = app.politicians[id]
+	}
+	return queryJSON(politicians, "politicians list")
+}
+
+func queryGovParams(app *PoliticianApp, _ url.Values) *types.ResponseQuery {
+	return queryJSON(app.govParams, "governance params")
+}
+
+func queryAuthParams(app *PoliticianApp, _ url.Values) *types.ResponseQuery {
+	return queryJSON(app.authParams, "auth params")
+}
+
+// queryProposals는 ID를 키로 하는 제안 목록을 ID 순으로 limit/offset만큼 반환합니다. status 파라미터로 상태를 거를 수 있습니다.
+func queryProposals(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	limit, offset, errRes := parsePagination(params)
+	if errRes != nil {
+		return errRes
+	}
+	status := params.Get("status")
+	if status != "" && !ptypes.ValidProposalStatus(status) {
+		return queryError(QueryCodeInvalidParam, fmt.Sprintf("unknown proposal status %q", status))
+	}
+	var ids []string
+	for id, proposal := range app.proposals {
+		if status == "" || proposal.Status == status {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	ids = paginate(ids, limit, offset)
+	proposals := make(map[string]*ptypes.Proposal, len(ids))
+	for _, id := range ids {
+		proposals[id] = app.proposals[id]
+	}
+	return queryJSON(proposals, "proposals list")
+}
+
+func queryAccount(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	address := params.Get("address")
+	if address == "" {
+		return queryError(QueryCodeInvalidParam, "address parameter required")
+	}
+	account, exists := app.accounts[address]
+	if !exists {
+		return queryError(QueryCodeNotFound, "account not found")
+	}
+	return queryJSON(account, "account")
+}
+
+// queryUser는 계정 정보로부터 공개 가능한 사용자 정보만 반환합니다.
+// 비밀번호 해시와 PIN은 체인에 저장하지 않으므로 응답에도 포함되지 않습니다.
+func queryUser(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	id := params.Get("id")
+	if id == "" {
+		return queryError(QueryCodeInvalidParam, "id parameter required")
+	}
+	account, exists := app.accounts[id]
+	if !exists {
+		return queryError(QueryCodeNotFound, "user not found")
+	}
+	user := ptypes.User{
+		ID:       account.Address,
+		Email:    account.Email,
+		IsActive: true,
+	}
+	return queryJSON(user, "user")
+}
+
+// queryPolitician은 정치인 하나를 반환합니다. id는 정치인 ID이거나 동명이인이 없는 이름입니다.
+func queryPolitician(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	id := params.Get("id")
+	if id == "" {
+		return queryError(QueryCodeInvalidParam, "id parameter required")
+	}
+	politician, matches := app.resolvePolitician(id)
+	if politician == nil {
+		if matches > 1 {
+			return queryError(QueryCodeInvalidParam, fmt.Sprintf("%d politicians share this name; use the politician id", matches))
+		}
+		return queryError(QueryCodeNotFound, "politician not found")
+	}
+	return queryJSON(politician, "politician")
+}
+
+func queryOrder(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	id := params.Get("id")
+	if id == "" {
+		return queryError(QueryCodeInvalidParam, "id parameter required")
+	}
+	order, exists := app.orders[id]
+	if !exists {
+		return queryError(QueryCodeNotFound, "order not found")
+	}
+	return queryJSON(order, "order")
+}
+
+// queryOrders는 특정 정치인의 주문 목록을 반환합니다. status, currency 파라미터로 상태와 결제 통화를 거를 수 있습니다.
+func queryOrders(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	politicianID := ptypes.PoliticianID(params.Get("politician_id"))
+	if politicianID == "" {
+		return queryError(QueryCodeInvalidParam, "politician_id parameter required")
+	}
+	status, currency := params.Get("status"), params.Get("currency")
+	return app.queryOrderList(params, func(order *ptypes.TradeOrder) bool {
+		return order.PoliticianID == politicianID && (status == "" || order.Status == status) && (currency == "" || order.Currency == currency)
+	})
+}
+
+// queryUserOrders는 특정 사용자의 주문 목록을 반환합니다. status, currency 파라미터로 상태와 결제 통화를 거를 수 있습니다.
+func queryUserOrders(app *PoliticianApp, params url.Values) *types.ResponseQuery {
+	userID := params.Get("user_id")
+	if userID == "" {
+		return queryError(QueryCodeInvalidParam, "user_id parameter required")
+	}
+	status, currency := params.Get("status"), params.Get("currency")
+	return app.queryOrderList(params, func(order *ptypes.TradeOrder) bool {
+		return order.UserID == userID && (status == "" || order.Status == status) && (currency == "" || order.Currency == currency)
+	})
+}
+
+// queryOrderList는 조건에 맞는 주문을 생성 순서대로 정렬하고 limit/offset으로 잘라 반환합니다.
+func (app *PoliticianApp) queryOrderList(params url.Values, match func(*ptypes.TradeOrder) bool) *types.ResponseQuery {
+	limit, offset, errRes := parsePagination(params)
+	if errRes != nil {
+		return errRes
+	}
+
+	orders := []ptypes.TradeOrder{}
+	for _, order := range app.orders {
+		if match(order) {
+			orders = append(orders, *order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].CreatedAt != orders[j].CreatedAt {
+			return orders[i].CreatedAt < orders[j].CreatedAt
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	return queryJSON(paginate(orders, limit, offset), "orders")
+}
+
+// parsePagination은 limit/offset 파라미터를 읽습니다. 값이 없으면 기본값을 사용합니다.
+func parsePagination(params url.Values) (int, int, *types.ResponseQuery) {
+	limit := defaultQueryLimit
+	offset := 0
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, 0, queryError(QueryCodeInvalidParam, "limit must be a positive integer")
+		}
+		if n > maxQueryLimit {
+			n = maxQueryLimit
+		}
+		limit = n
+	}
+	if v := params.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, queryError(QueryCodeInvalidParam, "offset must be a non-negative integer")
+		}
+		offset = n
+	}
+	return limit, offset, nil
+}
+
+// paginate는 정렬된 목록에서 offset부터 최대 limit개를 잘라 반환합니다. 범위를 벗어나면 빈 목록입니다.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// queryJSON은 값을 JSON으로 직렬화한 응답을 만듭니다.
+func queryJSON(v interface{}, what string) *types.ResponseQuery {
+	res, err := json.Marshal(v)
+	if err != nil {
+		return queryError(QueryCodeInternal, "failed to marshal "+what)
+	}
+	return &types.ResponseQuery{Code: QueryCodeOK, Value: res}
+}
+
+// queryError는 공통 코드스페이스를 가진 오류 응답을 만듭니다.
+func queryError(code uint32, log string) *types.ResponseQuery {
+	return &types.ResponseQuery{Code: code, Log: log, Codespace: queryCodespace}
+}
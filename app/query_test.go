@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/jclee286/politisian/pkg/smt"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+func TestListQueriesPaginateByID(t *testing.T) {
+	app := NewPoliticianApp(dbm.NewMemDB(), log.NewNopLogger())
+	for i := 0; i < 5; i++ {
+		id := ptypes.NewPoliticianID(fmt.Sprintf("정치인%d", i), "서울")
+		app.politicians[string(id)] = &ptypes.Politician{ID: id, Name: fmt.Sprintf("정치인%d", i), Region: "서울"}
+		proposalID := fmt.Sprintf("proposal-%d", 4-i)
+		status := ptypes.ProposalStatusVoting
+		if i == 0 {
+			status = ptypes.ProposalStatusPassed
+		}
+		app.proposals[proposalID] = &ptypes.Proposal{ID: proposalID, Status: status}
+	}
+	app.reindexPoliticians()
+	var politicianIDs []string
+	for id := range app.politicians {
+		politicianIDs = append(politicianIDs, id)
+	}
+	sort.Strings(politicianIDs)
+
+	tests := []struct {
+		name    string
+		handler func(*PoliticianApp, url.Values) string
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "proposals first page", handler: proposalKeys, query: "limit=2", want: []string{"proposal-0", "proposal-1"}},
+		{name: "proposals second page", handler: proposalKeys, query: "limit=2&offset=2", want: []string{"proposal-2", "proposal-3"}},
+		{name: "proposals past the end", handler: proposalKeys, query: "offset=10", want: []string{}},
+		{name: "proposals filtered by status", handler: proposalKeys, query: "status=voting&limit=3&offset=1", want: []string{"proposal-1", "proposal-2", "proposal-3"}},
+		{name: "proposals invalid limit", handler: proposalKeys, query: "limit=0", wantErr: true},
+		{name: "politicians default page", handler: politicianKeys, query: "", want: politicianIDs},
+		{name: "politicians middle page", handler: politicianKeys, query: "limit=2&offset=1", want: politicianIDs[1:3]},
+		{name: "politicians by region", handler: politicianKeys, query: "region=서울&limit=1&offset=4", want: politicianIDs[4:]},
+		{name: "politicians invalid offset", handler: politicianKeys, query: "offset=-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tt.handler(app, params)
+			if tt.wantErr {
+				if got != "error" {
+					t.Fatalf("got %s, want an error", got)
+				}
+				return
+			}
+			if want := fmt.Sprint(tt.want); got != want {
+				t.Errorf("keys = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// proposalKeys와 politicianKeys는 목록 쿼리 응답의 키를 정렬해 문자열로 만듭니다. 쿼리가 실패하면 "error"입니다.
+func proposalKeys(app *PoliticianApp, params url.Values) string {
+	return responseKeys[ptypes.Proposal](queryProposals(app, params))
+}
+
+func politicianKeys(app *PoliticianApp, params url.Values) string {
+	return responseKeys[ptypes.Politician](queryPoliticians(app, params))
+}
+
+func responseKeys[V any](res *types.ResponseQuery) string {
+	if res.Code != QueryCodeOK {
+		return "error"
+	}
+	var m map[string]*V
+	if err := json.Unmarshal(res.Value, &m); err != nil {
+		return "error"
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return fmt.Sprint(keys)
+}
+
+// 증명을 요청한 쿼리는 마지막 커밋의 값과, 그 값이 커밋한 앱 해시에 포함(또는 미포함)됨을 보이는 증명을 돌려줍니다.
+func TestProvedQueryMatchesAppHash(t *testing.T) {
+	chain := newTestChain(t, ptypes.GenesisState{Accounts: map[string]*ptypes.Account{"alice": {Address: "alice"}}})
+	chain.block(deposit("alice", 500))
+	appHash := chain.app.appHash
+
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(smt.ProofOpType, smt.ProofOpDecoder)
+	query := func(path string) *types.ResponseQuery {
+		t.Helper()
+		res, err := chain.app.Query(context.Background(), &types.RequestQuery{Path: path, Prove: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Code != QueryCodeOK || res.ProofOps == nil {
+			t.Fatalf("%s: code %d (%s), proof %v", path, res.Code, res.Log, res.ProofOps)
+		}
+		if res.Height != chain.height {
+			t.Fatalf("%s: height %d, want %d", path, res.Height, chain.height)
+		}
+		return res
+	}
+
+	res := query("/account?address=alice")
+	if err := prt.VerifyValue(res.ProofOps, appHash, smt.KeyPath(res.Key), res.Value); err != nil {
+		t.Fatalf("account proof does not verify against the app hash: %v", err)
+	}
+	account, err := ptypes.UnmarshalAccount(res.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.USDTBalance != 500 {
+		t.Fatalf("proved balance = %d, want 500", account.USDTBalance)
+	}
+	// 다른 값이나 다른 앱 해시로는 검증되지 않습니다.
+	account.USDTBalance = 1_000_000
+	if err := prt.VerifyValue(res.ProofOps, appHash, smt.KeyPath(res.Key), ptypes.MarshalAccount(account)); err == nil {
+		t.Fatal("forged account value verified")
+	}
+	if err := prt.VerifyValue(res.ProofOps, make([]byte, smt.HashSize), smt.KeyPath(res.Key), res.Value); err == nil {
+		t.Fatal("account proof verified against a different app hash")
+	}
+
+	missing := query("/account?address=nobody")
+	if len(missing.Value) != 0 {
+		t.Fatalf("missing account returned value %X", missing.Value)
+	}
+	if err := prt.VerifyAbsence(missing.ProofOps, appHash, smt.KeyPath(missing.Key)); err != nil {
+		t.Fatalf("absence proof does not verify against the app hash: %v", err)
+	}
+	if err := prt.VerifyAbsence(res.ProofOps, appHash, smt.KeyPath(res.Key)); err == nil {
+		t.Fatal("existing account verified as absent")
+	}
+}
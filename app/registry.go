@@ -0,0 +1,91 @@
+package app
+
+import (
+	"sort"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// politicianRegistry는 ID로 저장된 정치인을 이름과 지역으로도 찾기 위한 색인입니다.
+// 정치인 자체는 app.politicians에 있고, 색인은 상태에서 언제든 다시 만들 수 있으므로 저장하지 않습니다.
+type politicianRegistry struct {
+	byName   map[string][]ptypes.PoliticianID
+	byRegion map[string][]ptypes.PoliticianID
+	byKey    map[string]ptypes.PoliticianID // PoliticianDedupKey → ID, 중복 제안 검사용
+}
+
+// reindexPoliticians는 app.politicians 전체로 색인을 다시 만듭니다.
+// 상태를 불러오거나 제네시스, 스냅샷, 마이그레이션으로 정치인 맵을 교체한 뒤에 호출합니다.
+func (app *PoliticianApp) reindexPoliticians() {
+	app.registry = politicianRegistry{
+		byName:   make(map[string][]ptypes.PoliticianID),
+		byRegion: make(map[string][]ptypes.PoliticianID),
+		byKey:    make(map[string]ptypes.PoliticianID),
+	}
+	ids := make([]string, 0, len(app.politicians))
+	for id := range app.politicians {
+		ids = append(ids, id)
+	}
+	// 같은 이름의 정치인이 항상 같은 순서로 나오도록 ID 순으로 색인합니다.
+	sort.Strings(ids)
+	for _, id := range ids {
+		app.registry.add(app.politicians[id])
+	}
+}
+
+func (r *politicianRegistry) add(politician *ptypes.Politician) {
+	r.byName[politician.Name] = append(r.byName[politician.Name], politician.ID)
+	r.byRegion[politician.Region] = append(r.byRegion[politician.Region], politician.ID)
+	r.byKey[ptypes.PoliticianDedupKey(politician.Name, politician.Region, politician.Party)] = politician.ID
+}
+
+// registerPolitician은 새 정치인을 상태와 색인에 추가합니다.
+func (app *PoliticianApp) registerPolitician(politician *ptypes.Politician) {
+	app.politicians[string(politician.ID)] = politician
+	app.touchPolitician(string(politician.ID))
+	app.registry.add(politician)
+}
+
+// politicianByID는 정식 ID로 정치인을 찾습니다.
+func (app *PoliticianApp) politicianByID(id ptypes.PoliticianID) (*ptypes.Politician, bool) {
+	politician, exists := app.politicians[string(id)]
+	return politician, exists
+}
+
+// politicianByDedupKey는 이름, 지역, 정당을 정규화한 값이 같은 등록된 정치인을 찾습니다.
+func (app *PoliticianApp) politicianByDedupKey(name, region, party string) (ptypes.PoliticianID, bool) {
+	id, exists := app.registry.byKey[ptypes.PoliticianDedupKey(name, region, party)]
+	return id, exists
+}
+
+// politiciansByName은 이름이 같은 정치인들을 ID 순으로 반환합니다.
+func (app *PoliticianApp) politiciansByName(name string) []*ptypes.Politician {
+	return app.politiciansOf(app.registry.byName[name])
+}
+
+// politiciansByRegion은 지역이 같은 정치인들을 ID 순으로 반환합니다.
+func (app *PoliticianApp) politiciansByRegion(region string) []*ptypes.Politician {
+	return app.politiciansOf(app.registry.byRegion[region])
+}
+
+func (app *PoliticianApp) politiciansOf(ids []ptypes.PoliticianID) []*ptypes.Politician {
+	politicians := make([]*ptypes.Politician, 0, len(ids))
+	for _, id := range ids {
+		politicians = append(politicians, app.politicians[string(id)])
+	}
+	return politicians
+}
+
+// resolvePolitician은 클라이언트가 보낸 정치인 참조를 찾습니다. 참조는 정식 ID이거나,
+// 같은 이름의 정치인이 하나뿐인 경우 이름일 수 있습니다. 찾지 못하면 nil을, 이름이 여러 정치인에
+// 해당하면 nil과 후보 수를 반환합니다.
+func (app *PoliticianApp) resolvePolitician(ref string) (*ptypes.Politician, int) {
+	if politician, exists := app.politicianByID(ptypes.PoliticianID(ref)); exists {
+		return politician, 1
+	}
+	matches := app.politiciansByName(ref)
+	if len(matches) != 1 {
+		return nil, len(matches)
+	}
+	return matches[0], 1
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"testing"
+
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 등록된 정치인과 투표 중인 제안은 이름, 지역, 정당의 공백과 영문 대소문자가 달라도 중복으로 찾습니다.
+func TestPoliticianDuplicateDetection(t *testing.T) {
+	politician := &ptypes.Politician{Name: "Kim Minsu", Region: "Seoul", Party: "Independent"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := newTestChain(t, ptypes.GenesisState{Politicians: roster}).app
+	open := &ptypes.Proposal{ID: "open", Status: ptypes.ProposalStatusVoting,
+		Politician: ptypes.Politician{ID: ptypes.NewPoliticianID("Lee Jiwon", "Busan"), Name: "Lee Jiwon", Region: "Busan", Party: "Green"}}
+	closed := &ptypes.Proposal{ID: "closed", Status: ptypes.ProposalStatusRejected,
+		Politician: ptypes.Politician{ID: ptypes.NewPoliticianID("Park Hana", "Daegu"), Name: "Park Hana", Region: "Daegu"}}
+	app.proposals = map[string]*ptypes.Proposal{open.ID: open, closed.ID: closed}
+
+	tests := []struct {
+		name                 string
+		pName, region, party string
+		wantPolitician       bool
+		wantProposal         string
+	}{
+		{name: "registered politician", pName: "Kim Minsu", region: "Seoul", party: "Independent", wantPolitician: true},
+		{name: "registered politician with extra spaces", pName: " Kim  Minsu ", region: "Seoul ", party: "\tIndependent", wantPolitician: true},
+		{name: "registered politician in another case", pName: "kim minsu", region: "SEOUL", party: "independent", wantPolitician: true},
+		{name: "registered name in another region", pName: "Kim Minsu", region: "Busan", party: "Independent"},
+		{name: "registered name in another party", pName: "Kim Minsu", region: "Seoul", party: "Green"},
+		{name: "open proposal", pName: "Lee Jiwon", region: "Busan", party: "Green", wantProposal: "open"},
+		{name: "open proposal with extra spaces and case", pName: "LEE   JIWON", region: " busan", party: "green ", wantProposal: "open"},
+		// 정당이 달라도 이름과 지역이 같으면 ID가 같으므로 진행 중인 제안으로 찾습니다.
+		{name: "open proposal's ID under another party", pName: "Lee Jiwon", region: "Busan", party: "Blue", wantProposal: "open"},
+		{name: "closed proposal", pName: "Park Hana", region: "Daegu"},
+		{name: "unrelated", pName: "Choi Yuna", region: "Incheon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, exists := app.politicianByDedupKey(tt.pName, tt.region, tt.party)
+			if exists != tt.wantPolitician || (exists && id != politician.ID) {
+				t.Errorf("politicianByDedupKey() = %s, %v; want registered %v", id, exists, tt.wantPolitician)
+			}
+			got := ""
+			if proposal, exists := app.openProposalFor(ptypes.NewPoliticianID(tt.pName, tt.region), tt.pName, tt.region, tt.party); exists {
+				got = proposal.ID
+			}
+			if got != tt.wantProposal {
+				t.Errorf("openProposalFor() = %q, want %q", got, tt.wantProposal)
+			}
+		})
+	}
+}
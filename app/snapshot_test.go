@@ -0,0 +1,148 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// newSnapshotChain은 주문이 몇 개 있는 체인을 만들고 높이 2마다 스냅샷을 만들어 높이 4까지 진행합니다.
+// 스냅샷을 만드는 중에 다음 블록이 커밋되면 그 스냅샷은 버려지므로 블록마다 기다립니다.
+func newSnapshotChain(t *testing.T) *testChain {
+	t.Helper()
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts:    map[string]*ptypes.Account{"buyer": {Address: "buyer", USDTBalance: 10_000}},
+	})
+	if err := chain.app.EnableSnapshots(SnapshotOptions{Dir: t.TempDir(), Interval: 2, KeepRecent: 1}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		chain.block(placeOrder("buyer", ptypes.PlaceOrderMsg{PoliticianID: politician.ID, OrderType: "buy", Currency: "USDT", Quantity: 1, Price: int64(100 + i)}))
+		chain.app.WaitForSnapshots()
+	}
+	return chain
+}
+
+// restoreFrom은 source의 스냅샷 청크를 새 앱에 적용하고, 마지막 청크의 결과를 반환합니다.
+func restoreFrom(t *testing.T, source *PoliticianApp, target *PoliticianApp, snapshot *types.Snapshot, appHash []byte) types.ResponseApplySnapshotChunk_Result {
+	t.Helper()
+	ctx := context.Background()
+	offer, err := target.OfferSnapshot(ctx, &types.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.Result != types.ResponseOfferSnapshot_ACCEPT {
+		t.Fatalf("offer result = %v, want ACCEPT", offer.Result)
+	}
+	var result types.ResponseApplySnapshotChunk_Result
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := source.LoadSnapshotChunk(ctx, &types.RequestLoadSnapshotChunk{Height: snapshot.Height, Format: snapshot.Format, Chunk: i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		applied, err := target.ApplySnapshotChunk(ctx, &types.RequestApplySnapshotChunk{Index: i, Chunk: chunk.Chunk})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = applied.Result
+	}
+	return result
+}
+
+// withStateVersion은 메타데이터의 상태 버전만 바꾼 스냅샷 사본을 만듭니다.
+func withStateVersion(t *testing.T, snapshot *types.Snapshot, version uint64) *types.Snapshot {
+	t.Helper()
+	metadata, err := parseSnapshotMetadata(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata.StateVersion = version
+	bz, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(bz)
+	changed := *snapshot
+	changed.Metadata, changed.Hash = bz, hash[:]
+	return &changed
+}
+
+func storedStateVersion(t *testing.T, db dbm.DB) uint64 {
+	t.Helper()
+	bz, err := db.Get(commitVersionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// 스냅샷은 Commit 뒤 백그라운드에서 만들어지고, 상태 버전을 기록하며, 복원하면 같은 상태와 앱 해시가 됩니다.
+func TestSnapshotCreateAndRestore(t *testing.T) {
+	chain := newSnapshotChain(t)
+	ctx := context.Background()
+	list, err := chain.app.ListSnapshots(ctx, &types.RequestListSnapshots{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Snapshots) != 1 || list.Snapshots[0].Height != 4 || list.Snapshots[0].Format != snapshotFormat {
+		t.Fatalf("snapshots = %+v, want only the format %d snapshot at height 4", list.Snapshots, snapshotFormat)
+	}
+	snapshot := list.Snapshots[0]
+	metadata, err := parseSnapshotMetadata(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.StateVersion != currentStateVersion {
+		t.Fatalf("snapshot state version = %d, want %d", metadata.StateVersion, currentStateVersion)
+	}
+
+	db := dbm.NewMemDB()
+	restored := NewPoliticianApp(db, log.NewNopLogger())
+	if result := restoreFrom(t, chain.app, restored, snapshot, chain.app.appHash); result != types.ResponseApplySnapshotChunk_ACCEPT {
+		t.Fatalf("restore result = %v, want ACCEPT", result)
+	}
+	if restored.height != 4 || !bytes.Equal(restored.appHash, chain.app.appHash) {
+		t.Fatalf("restored height %d hash %X, want 4 %X", restored.height, restored.appHash, chain.app.appHash)
+	}
+	if len(restored.orders) != 4 || len(restored.orderBooks.books) != 1 {
+		t.Fatalf("restored %d orders in %d books, want 4 in 1", len(restored.orders), len(restored.orderBooks.books))
+	}
+	if v := storedStateVersion(t, db); v != currentStateVersion {
+		t.Fatalf("stored state version = %d, want %d", v, currentStateVersion)
+	}
+}
+
+// 상태 버전이 이 노드와 다른 스냅샷은 거부됩니다.
+func TestSnapshotRestoreChecksStateVersion(t *testing.T) {
+	chain := newSnapshotChain(t)
+	list, err := chain.app.ListSnapshots(context.Background(), &types.RequestListSnapshots{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := list.Snapshots[0]
+
+	for _, version := range []uint64{currentStateVersion - 1, currentStateVersion + 1} {
+		offer, err := NewPoliticianApp(dbm.NewMemDB(), log.NewNopLogger()).OfferSnapshot(context.Background(),
+			&types.RequestOfferSnapshot{Snapshot: withStateVersion(t, snapshot, version), AppHash: chain.app.appHash})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if offer.Result != types.ResponseOfferSnapshot_REJECT {
+			t.Errorf("offer of state version %d = %v, want REJECT", version, offer.Result)
+		}
+	}
+}
@@ -1,85 +1,331 @@
 package app
 
 import (
-	"crypto/sha256"
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	dbm "github.com/cometbft/cometbft-db"
 	ptypes "github.com/jclee286/politisian/pkg/types"
 )
 
+// 상태는 엔티티마다 하나의 키로 저장되고, 같은 키-값 쌍으로 희소 머클 트리를 구성합니다.
+// 앱 해시는 트리의 루트이므로 키마다 포함 증명을 만들 수 있습니다.
+//
+//	accounts/<주소>        ptypes.Account
+//	politicians/<ID>       ptypes.Politician (ID는 ptypes.NewPoliticianID)
+//	proposals/<ID>         ptypes.Proposal
+//	orders/<ID>            ptypes.TradeOrder
+//	trades/<ID>            ptypes.Trade
+//	sequence/orders        마지막 주문 접수 순서 (big endian uint64)
+//	params/governance      ptypes.GovParams
+//	params/auth            ptypes.AuthParams (등록 키가 없으면 저장하지 않음)
+//
+// commit/ 아래의 키는 마지막 커밋 정보로, 트리(앱 해시)에는 포함되지 않습니다.
+const (
+	accountPrefix    = "accounts/"
+	politicianPrefix = "politicians/"
+	proposalPrefix   = "proposals/"
+	orderPrefix      = "orders/"
+	tradePrefix      = "trades/"
+	orderSequenceKey = "sequence/orders"
+	govParamsKey     = "params/governance"
+	authParamsKey    = "params/auth"
+)
+
 var (
-	stateKey = []byte("stateKey")
+	commitVersionKey = []byte("commit/version")
+	commitHeightKey  = []byte("commit/height")
+	commitAppHashKey = []byte("commit/app_hash")
+
+	// legacyStateKey는 키별 저장 이전에 전체 상태를 하나의 블롭으로 저장하던 키입니다.
+	legacyStateKey = []byte("stateKey")
 )
 
-// AppState는 애플리케이션의 전체 상태를 나타내는 구조체입니다.
-type AppState struct {
-	Height      int64                        `json:"height"`
-	AppHash     []byte                       `json:"app_hash"`
-	Accounts    map[string]*ptypes.Account    `json:"accounts"`
-	Proposals   map[string]*ptypes.Proposal   `json:"proposals"`
-	Politicians map[string]*ptypes.Politician `json:"politicians"`
+// currentStateVersion은 현재 상태 스키마 버전입니다.
+// 버전 1은 이전 노드가 JSON 블롭 하나로 저장한 상태이고(버전 필드가 없어 0으로 읽힙니다), 버전 2부터 키별로 저장합니다.
+// 스키마가 바뀌면 버전을 올리고 migrations.go에 마이그레이션을 추가합니다.
+const currentStateVersion = 2
+
+// touch는 키가 이번 블록에서 바뀌었음을 기록합니다.
+// 표시된 키만 다음 hashState에서 트리에 반영되고 Commit에서 저장되므로,
+// 상태를 바꾸는 코드는 바꾼 엔티티의 키를 반드시 표시해야 합니다.
+func (app *PoliticianApp) touch(prefix, id string) {
+	app.dirty[prefix+id] = true
+}
+
+func (app *PoliticianApp) touchAccount(id string)    { app.touch(accountPrefix, id) }
+func (app *PoliticianApp) touchPolitician(id string) { app.touch(politicianPrefix, id) }
+func (app *PoliticianApp) touchProposal(id string)   { app.touch(proposalPrefix, id) }
+func (app *PoliticianApp) touchOrder(id string)      { app.touch(orderPrefix, id) }
+func (app *PoliticianApp) touchTrade(id string)      { app.touch(tradePrefix, id) }
+
+// touchAll은 메모리의 모든 엔티티를 바뀐 것으로 표시합니다. 제네시스나 이전 형식 변환처럼 상태 전체를 교체할 때 사용합니다.
+func (app *PoliticianApp) touchAll() {
+	for id := range app.accounts {
+		app.touchAccount(id)
+	}
+	for id := range app.politicians {
+		app.touchPolitician(id)
+	}
+	for id := range app.proposals {
+		app.touchProposal(id)
+	}
+	for id := range app.orders {
+		app.touchOrder(id)
+	}
+	for id := range app.trades {
+		app.touchTrade(id)
+	}
+	app.dirty[orderSequenceKey] = true
+	app.dirty[govParamsKey] = true
+	app.dirty[authParamsKey] = true
 }
 
-// saveState는 현재 애플리케이션 상태를 데이터베이스에 저장합니다.
+// encodeKey는 키에 해당하는 현재 메모리 값을 인코딩합니다. 엔티티가 삭제되었으면 false를 반환합니다.
+func (app *PoliticianApp) encodeKey(key string) ([]byte, bool) {
+	switch {
+	case strings.HasPrefix(key, accountPrefix):
+		if v, ok := app.accounts[key[len(accountPrefix):]]; ok {
+			return ptypes.MarshalAccount(v), true
+		}
+	case strings.HasPrefix(key, politicianPrefix):
+		if v, ok := app.politicians[key[len(politicianPrefix):]]; ok {
+			return ptypes.MarshalPolitician(v), true
+		}
+	case strings.HasPrefix(key, proposalPrefix):
+		if v, ok := app.proposals[key[len(proposalPrefix):]]; ok {
+			return ptypes.MarshalProposal(v), true
+		}
+	case strings.HasPrefix(key, orderPrefix):
+		if v, ok := app.orders[key[len(orderPrefix):]]; ok {
+			return ptypes.MarshalTradeOrder(v), true
+		}
+	case strings.HasPrefix(key, tradePrefix):
+		if v, ok := app.trades[key[len(tradePrefix):]]; ok {
+			return ptypes.MarshalTrade(v), true
+		}
+	case key == orderSequenceKey:
+		if app.orderSequence != 0 {
+			return binary.BigEndian.AppendUint64(nil, uint64(app.orderSequence)), true
+		}
+	case key == govParamsKey:
+		return ptypes.MarshalGovParams(&app.govParams), true
+	case key == authParamsKey:
+		// 등록 키가 없는 체인의 앱 해시는 이 키가 도입되기 전과 같습니다.
+		if len(app.authParams.KeyRegistrar) > 0 {
+			return ptypes.MarshalAuthParams(&app.authParams), true
+		}
+	}
+	return nil, false
+}
+
+// hashState는 이번 블록에서 바뀐 키만 트리에 반영하고 app.appHash를 새 루트로 갱신합니다.
+// 반영된 값은 Commit에서 저장될 때까지 pendingWrites에 보관됩니다.
+func (app *PoliticianApp) hashState() {
+	for key := range app.dirty {
+		value, exists := app.encodeKey(key)
+		if exists {
+			app.tree.Set([]byte(key), value)
+		} else {
+			app.tree.Delete([]byte(key))
+		}
+		app.pendingWrites[key] = value
+	}
+	app.dirty = make(map[string]bool)
+	app.appHash = app.tree.Root()
+}
+
+// saveState는 마지막 커밋 이후 바뀐 키와 커밋 정보를 하나의 배치로 저장합니다.
 func (app *PoliticianApp) saveState() error {
-	state := AppState{
-		Height:      app.height,
-		AppHash:     app.appHash,
-		Accounts:    app.accounts,
-		Proposals:   app.proposals,
-		Politicians: app.politicians,
-	}
-	stateBytes, err := json.Marshal(state)
-	if err != nil {
+	return app.writeState(currentStateVersion)
+}
+
+// writeState는 saveState와 같지만 상태 버전을 지정합니다. 이전 버전의 스냅샷을 복원할 때 사용합니다.
+func (app *PoliticianApp) writeState(version uint64) error {
+	batch := app.db.NewBatch()
+	defer batch.Close()
+
+	for key, value := range app.pendingWrites {
+		var err error
+		if value == nil {
+			err = batch.Delete([]byte(key))
+		} else {
+			err = batch.Set([]byte(key), value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(commitVersionKey, binary.BigEndian.AppendUint64(nil, version)); err != nil {
+		return err
+	}
+	if err := batch.Set(commitHeightKey, binary.BigEndian.AppendUint64(nil, uint64(app.height))); err != nil {
+		return err
+	}
+	if err := batch.Set(commitAppHashKey, app.appHash); err != nil {
+		return err
+	}
+	// 이전 형식에서 변환된 경우 더 이상 필요 없는 블롭을 지웁니다.
+	if err := batch.Delete(legacyStateKey); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
 		return err
 	}
-	app.logger.Debug("Saving state", "height", state.Height, "appHash", fmt.Sprintf("%X", state.AppHash))
-	return app.db.SetSync(stateKey, stateBytes)
+	app.logger.Debug("Saved state", "height", app.height, "changed_keys", len(app.pendingWrites), "appHash", fmt.Sprintf("%X", app.appHash))
+	app.pendingWrites = make(map[string][]byte)
+	app.committedTree = app.tree.Snapshot()
+	return nil
 }
 
-// loadState는 데이터베이스에서 애플리케이션 상태를 불러옵니다.
+// loadState는 데이터베이스에서 애플리케이션 상태를 불러오고 정치인 색인과 미체결 주문 색인을 만듭니다.
 func (app *PoliticianApp) loadState() error {
-	stateBytes, err := app.db.Get(stateKey)
+	if err := app.loadStoredState(); err != nil {
+		return err
+	}
+	app.reindexPoliticians()
+	app.reindexOrders()
+	return nil
+}
+
+// loadStoredState는 키별로 저장된 상태를, 없으면 이전 형식의 상태 블롭을 읽습니다.
+func (app *PoliticianApp) loadStoredState() error {
+	versionBytes, err := app.db.Get(commitVersionKey)
 	if err != nil {
-		// Get에서 발생한 다른 모든 에러는 심각한 문제로 간주합니다.
 		return err
 	}
 	// DB에 키가 없는 경우 Get 메서드는 (nil, nil)을 반환합니다.
-	// 따라서 stateBytes의 길이로 존재 여부를 확인하는 것이 가장 안전합니다.
+	if len(versionBytes) == 0 {
+		return app.loadLegacyState()
+	}
+	// 키별 저장은 현재 버전에서 처음 도입되었으므로 다른 버전은 이 노드가 읽을 수 없습니다.
+	version := binary.BigEndian.Uint64(versionBytes)
+	if version != currentStateVersion {
+		return fmt.Errorf("state version %d is not supported (expected %d)", version, currentStateVersion)
+	}
+
+	heightBytes, err := app.db.Get(commitHeightKey)
+	if err != nil {
+		return err
+	}
+	app.height = int64(binary.BigEndian.Uint64(heightBytes))
+	if app.appHash, err = app.db.Get(commitAppHashKey); err != nil {
+		return err
+	}
+
+	if err := loadEntities(app, accountPrefix, ptypes.UnmarshalAccount, app.accounts); err != nil {
+		return err
+	}
+	if err := loadEntities(app, politicianPrefix, ptypes.UnmarshalPolitician, app.politicians); err != nil {
+		return err
+	}
+	if err := loadEntities(app, proposalPrefix, ptypes.UnmarshalProposal, app.proposals); err != nil {
+		return err
+	}
+	if err := loadEntities(app, orderPrefix, ptypes.UnmarshalTradeOrder, app.orders); err != nil {
+		return err
+	}
+	if err := loadEntities(app, tradePrefix, ptypes.UnmarshalTrade, app.trades); err != nil {
+		return err
+	}
+	sequenceBytes, err := app.db.Get([]byte(orderSequenceKey))
+	if err != nil {
+		return err
+	}
+	if len(sequenceBytes) > 0 {
+		app.orderSequence = int64(binary.BigEndian.Uint64(sequenceBytes))
+		app.tree.Set([]byte(orderSequenceKey), sequenceBytes)
+	}
+	paramsBytes, err := app.db.Get([]byte(govParamsKey))
+	if err != nil {
+		return err
+	}
+	if len(paramsBytes) > 0 {
+		params, err := ptypes.UnmarshalGovParams(paramsBytes)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", govParamsKey, err)
+		}
+		app.govParams = *params
+		app.tree.Set([]byte(govParamsKey), paramsBytes)
+	}
+	authBytes, err := app.db.Get([]byte(authParamsKey))
+	if err != nil {
+		return err
+	}
+	if len(authBytes) > 0 {
+		params, err := ptypes.UnmarshalAuthParams(authBytes)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", authParamsKey, err)
+		}
+		app.authParams = *params
+		app.tree.Set([]byte(authParamsKey), authBytes)
+	}
+
+	// 다시 만든 트리의 루트가 마지막 커밋의 앱 해시와 같아야 합니다.
+	if root := app.tree.Root(); !bytes.Equal(root, app.appHash) {
+		return fmt.Errorf("rebuilt state root %X does not match committed app hash %X", root, app.appHash)
+	}
+	app.committedTree = app.tree.Snapshot()
+	app.logger.Info("Loaded state from DB", "height", app.height, "keys", app.tree.Size(), "appHash", fmt.Sprintf("%X", app.appHash))
+	return nil
+}
+
+// loadEntities는 접두사 아래의 모든 엔티티를 읽어 맵과 트리에 넣습니다.
+func loadEntities[V any](app *PoliticianApp, prefix string, decode func([]byte) (*V, error), into map[string]*V) error {
+	iter, err := dbm.IteratePrefix(app.db, []byte(prefix))
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		value, err := decode(iter.Value())
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", iter.Key(), err)
+		}
+		into[string(iter.Key()[len(prefix):])] = value
+		app.tree.Set(iter.Key(), iter.Value())
+	}
+	return iter.Error()
+}
+
+// loadLegacyState는 키별 저장 이전 노드가 JSON 블롭 하나로 저장한 상태를 읽어 현재 스키마로 마이그레이션합니다.
+// 읽은 상태는 모든 키가 바뀐 것으로 표시되어 다음 Commit에서 키별 형식으로 다시 저장됩니다.
+func (app *PoliticianApp) loadLegacyState() error {
+	stateBytes, err := app.db.Get(legacyStateKey)
+	if err != nil {
+		return err
+	}
 	if len(stateBytes) == 0 {
 		app.logger.Info("Initial state, no data to load")
 		return nil // 데이터가 비어있으면 초기 상태로 시작
 	}
 
-	var state AppState
+	var state *ptypes.AppState
 	if err := json.Unmarshal(stateBytes, &state); err != nil {
 		return err
 	}
+	// 이전 버전 노드가 저장한 상태라면 현재 스키마로 마이그레이션합니다.
+	if err := migrateState(state); err != nil {
+		return fmt.Errorf("failed to migrate state: %w", err)
+	}
 
 	app.height = state.Height
 	app.appHash = state.AppHash
 	app.accounts = state.Accounts
 	app.proposals = state.Proposals
 	app.politicians = state.Politicians
+	app.orders = state.Orders
+	app.trades = state.Trades
+	app.orderSequence = state.OrderSequence
+	app.govParams = *state.GovParams
+	if state.AuthParams != nil {
+		app.authParams = *state.AuthParams
+	}
+	app.touchAll()
 
-	app.logger.Info("Loaded state from DB", "height", app.height, "appHash", fmt.Sprintf("%X", app.appHash))
+	app.logger.Info("Loaded legacy state blob; it will be rewritten per key on the next commit", "version", state.Version, "height", app.height, "appHash", fmt.Sprintf("%X", app.appHash))
 	return nil
 }
-
-// hashState는 현재 상태의 해시를 계산하여 app.appHash를 업데이트합니다.
-func (app *PoliticianApp) hashState() {
-	state := AppState{
-		Height:      app.height,
-		Accounts:    app.accounts,
-		Proposals:   app.proposals,
-		Politicians: app.politicians,
-	}
-	stateBytes, err := json.Marshal(state)
-	if err != nil {
-		// 이 에러는 발생해서는 안됩니다. 발생 시 시스템적인 문제입니다.
-		panic(err)
-	}
-	hash := sha256.Sum256(stateBytes)
-	app.appHash = hash[:]
-}
@@ -0,0 +1,479 @@
+package app
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/cometbft/cometbft/abci/types"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 트랜잭션 결과 코드입니다. 코드마다 뜻이 하나이고, CheckTx와 FinalizeBlock이 같은 거부에 같은 코드를 반환합니다.
+// 한 번 쓴 코드는 뜻을 바꾸거나 다른 실패에 다시 쓰지 않습니다 (4, 61은 더 이상 쓰지 않습니다).
+const (
+	// 디코딩과 인증
+	TxCodeDecodeFailed   uint32 = 1  // 봉투나 트랜잭션 데이터를 해석할 수 없음
+	TxCodeUnknownAction  uint32 = 10 // 등록되지 않은 액션
+	TxCodeInternalAction uint32 = 11 // 매칭 엔진만 만드는 액션(execute_trade)을 제출함
+	TxCodeBadSignature   uint32 = 12 // 공개키 형식이나 서명이 올바르지 않음
+	TxCodeUnauthorized   uint32 = 13 // 서명자가 이 계정과 액션에 서명할 수 없음
+	TxCodeBadNonce       uint32 = 14 // nonce가 마지막으로 사용한 값보다 크지 않음
+	TxCodeInvalidMsg     uint32 = 15 // 메시지가 없거나 ValidateBasic을 통과하지 못함
+
+	// 계정
+	TxCodeUserIDExists      uint32 = 2  // 이미 있는 사용자 ID로 계정을 만듦
+	TxCodeUserIDRequired    uint32 = 3  // 사용자 ID가 비어 있음
+	TxCodeAccountNotFound   uint32 = 30 // 서명한 계정이 없음
+	TxCodeKeyAlreadyBound   uint32 = 34 // 이미 공개키가 등록된 계정에 키를 등록함
+	TxCodeInsufficientFunds uint32 = 6  // 사용 가능한(동결되지 않은) 잔액이 부족함
+
+	// 주문
+	TxCodePoliticianNotFound uint32 = 5  // 주문할 정치인이 없음
+	TxCodePoliticianDelisted uint32 = 7  // 주문할 정치인이 상장 폐지됨
+	TxCodeNoLiquidity        uint32 = 8  // 시장가 주문과 체결할 호가가 없음
+	TxCodePostOnlyCrosses    uint32 = 9  // post-only 주문이 바로 체결되는 가격임
+	TxCodeOrderTooLarge      uint32 = 16 // 주문 금액이 int64를 넘음
+	TxCodeOrderExpired       uint32 = 17 // 접수되는 블록에서 바로 만료될 주문
+	TxCodeOrderNotFound      uint32 = 31 // 주문이 없음
+	TxCodeNotOrderOwner      uint32 = 32 // 다른 계정의 주문을 취소하거나 에스크로를 해제하려 함
+	TxCodeOrderClosed        uint32 = 33 // 이미 종료된 주문을 취소하려 함
+	TxCodeOrderOpen          uint32 = 35 // 미체결 주문의 에스크로를 해제하려 함
+
+	// 제안
+	TxCodeProposerNotFound   uint32 = 20 // 제안할 계정이 없음
+	TxCodeInvalidPolitician  uint32 = 21 // 정치인 정보의 형식이 올바르지 않음
+	TxCodePoliticianExists   uint32 = 22 // 같은 정치인이 이미 등록됨
+	TxCodeProposerTooNew     uint32 = 23 // 제안하기에 계정이 너무 새로움
+	TxCodeNoProposerDeposit  uint32 = 24 // 보증금에 쓸 USDT가 부족함
+	TxCodeInvalidTallyMode   uint32 = 25 // 알 수 없는 집계 방식
+	TxCodeWithdrawNotFound   uint32 = 26 // 철회할 제안이 없음
+	TxCodeNotProposer        uint32 = 27 // 제안자가 아닌 계정이 철회하려 함
+	TxCodeWithdrawClosed     uint32 = 28 // 이미 종료된 제안을 철회하려 함
+	TxCodeProposalOpen       uint32 = 29 // 같은 정치인에 대한 제안이 투표 중임
+	TxCodeTargetNotFound     uint32 = 60 // 수정·상장 폐지할 정치인이 없음
+	TxCodeTargetDelisted     uint32 = 62 // 수정·상장 폐지할 정치인이 이미 상장 폐지됨
+	TxCodeAmendmentUnchanged uint32 = 63 // 수정 제안이 아무것도 바꾸지 않음
+
+	// 투표
+	TxCodeVoteProposalNotFound uint32 = 40 // 투표할 제안이 없음
+	TxCodeAlreadyVoted         uint32 = 41 // 이미 투표함
+	TxCodeVoterNotFound        uint32 = 42 // 투표할 계정이 없음
+	TxCodeProposerVote         uint32 = 43 // 제안자가 자신의 제안에 투표함
+	TxCodeVoterTooNew          uint32 = 44 // 투표하기에 계정이 너무 새로움
+	TxCodeVotingEnded          uint32 = 45 // 투표 기간이 끝남 (투표와 철회)
+	TxCodeVoteProposalClosed   uint32 = 46 // 이미 종료된 제안에 투표함
+
+	// 추천 보상
+	TxCodeReferralAccountNotFound uint32 = 50 // 보상을 받을 계정이 없음
+	TxCodeNoReferralCredits       uint32 = 51 // 추천 크레딧이 없음
+	TxCodeReferralTargetMissing   uint32 = 52 // 정치인을 지정하지 않음
+	TxCodeReferralAlreadyClaimed  uint32 = 53 // 이미 이 정치인의 코인을 받음
+	TxCodeReferralTargetNotFound  uint32 = 54 // 지정한 정치인이 없음
+	TxCodeReferralSupplyExhausted uint32 = 55 // 정치인의 남은 발행량이 부족함
+	TxCodeReferralTargetAmbiguous uint32 = 56 // 같은 이름의 정치인이 여럿임
+	TxCodeReferralTargetDelisted  uint32 = 57 // 지정한 정치인이 상장 폐지됨
+)
+
+// txError는 트랜잭션 검증 실패를 나타냅니다. CheckTx와 FinalizeBlock이 같은 코드와 메시지를 반환합니다.
+type txError struct {
+	Code uint32
+	Log  string
+}
+
+func newTxError(code uint32, log string) *txError {
+	return &txError{Code: code, Log: log}
+}
+
+func (e *txError) Error() string {
+	return e.Log
+}
+
+// execResult는 FinalizeBlock용 실패 결과를 만듭니다.
+func (e *txError) execResult() *types.ExecTxResult {
+	return &types.ExecTxResult{Code: e.Code, Log: e.Log}
+}
+
+// checkResult는 CheckTx용 실패 결과를 만듭니다.
+func (e *txError) checkResult() *types.ResponseCheckTx {
+	return &types.ResponseCheckTx{Code: e.Code, Log: e.Log}
+}
+
+// txRoute는 액션별 검증 함수와 실행 함수를 묶습니다.
+// 실행 함수는 검증 함수가 통과한 뒤에만 호출되므로 같은 검사를 반복하지 않습니다.
+type txRoute struct {
+	validate func(app *PoliticianApp, txData *ptypes.TxData) *txError
+	execute  func(app *PoliticianApp, txData *ptypes.TxData) *types.ExecTxResult
+}
+
+// txRoutes는 클라이언트가 제출할 수 있는 모든 액션입니다.
+var txRoutes = map[string]txRoute{
+	"create_profile":        {(*PoliticianApp).validateCreateProfile, (*PoliticianApp).handleCreateProfile},
+	"update_supporters":     {(*PoliticianApp).validateUpdateSupporters, (*PoliticianApp).updateSupporters},
+	"propose_politician":    {(*PoliticianApp).validateProposePolitician, (*PoliticianApp).proposePolitician},
+	"propose_amendment":     {(*PoliticianApp).validateProposeAmendment, (*PoliticianApp).handleProposeAmendment},
+	"propose_delisting":     {(*PoliticianApp).validateProposeDelisting, (*PoliticianApp).handleProposeDelisting},
+	"vote_on_proposal":      {(*PoliticianApp).validateVoteOnProposal, (*PoliticianApp).handleVoteOnProposal},
+	"withdraw_proposal":     {(*PoliticianApp).validateWithdrawProposal, (*PoliticianApp).handleWithdrawProposal},
+	"claim_referral_reward": {(*PoliticianApp).validateClaimReferralReward, (*PoliticianApp).handleClaimReferralReward},
+	"place_order":           {(*PoliticianApp).validatePlaceOrder, (*PoliticianApp).handlePlaceOrder},
+	"cancel_order":          {(*PoliticianApp).validateCancelOrder, (*PoliticianApp).handleCancelOrder},
+	"release_escrow":        {(*PoliticianApp).validateReleaseEscrow, (*PoliticianApp).handleReleaseEscrow},
+	"deposit_stablecoin":    {(*PoliticianApp).validateDepositStablecoin, (*PoliticianApp).handleDepositStablecoin},
+	"withdraw_stablecoin":   {(*PoliticianApp).validateWithdrawStablecoin, (*PoliticianApp).handleWithdrawStablecoin},
+	bindKeyAction:           {(*PoliticianApp).validateBindAccountKey, (*PoliticianApp).handleBindAccountKey},
+}
+
+// decodeTx는 서명된 트랜잭션 봉투를 풀어 chainID 체인에 대한 서명을 확인하고, TxData와 해당 액션의 라우트를 찾습니다.
+func decodeTx(tx []byte, chainID string) (*ptypes.SignedTx, *ptypes.TxData, txRoute, *txError) {
+	signed, err := ptypes.UnmarshalSignedTx(tx)
+	if err != nil || len(signed.Tx) == 0 {
+		return nil, nil, txRoute{}, newTxError(TxCodeDecodeFailed, "서명된 트랜잭션을 해석할 수 없습니다")
+	}
+	if txErr := verifySignature(signed, chainID); txErr != nil {
+		return signed, nil, txRoute{}, txErr
+	}
+	txData, err := ptypes.UnmarshalTxData(signed.Tx)
+	if err != nil {
+		return signed, nil, txRoute{}, newTxError(TxCodeDecodeFailed, "트랜잭션 데이터를 해석할 수 없습니다")
+	}
+	if txData.Action == "execute_trade" {
+		// 체결은 place_order 처리 중 매칭 엔진이 결정적으로 생성합니다.
+		return signed, txData, txRoute{}, newTxError(TxCodeInternalAction, "execute_trade는 매칭 엔진이 만드는 액션이므로 제출할 수 없습니다")
+	}
+	route, ok := txRoutes[txData.Action]
+	if !ok {
+		return signed, txData, txRoute{}, newTxError(TxCodeUnknownAction, "알 수 없는 액션입니다")
+	}
+	if err := txData.ValidateBasic(); err != nil {
+		return signed, txData, txRoute{}, newTxError(TxCodeInvalidMsg, "잘못된 메시지입니다: "+err.Error())
+	}
+	return signed, txData, route, nil
+}
+
+// --- 액션별 검증 함수 ---
+
+func (app *PoliticianApp) validateCreateProfile(txData *ptypes.TxData) *txError {
+	if txData.UserID == "" {
+		return newTxError(TxCodeUserIDRequired, "사용자 ID가 필요합니다")
+	}
+	if _, exists := app.accounts[txData.UserID]; exists {
+		return newTxError(TxCodeUserIDExists, "이미 존재하는 사용자 ID입니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateUpdateSupporters(txData *ptypes.TxData) *txError {
+	if _, exists := app.accounts[txData.UserID]; !exists {
+		return newTxError(TxCodeAccountNotFound, "수정할 계정을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// validateProposer는 모든 종류의 제안에 공통인 제안자 조건(계정 나이, 보증금)과 집계 방식을 확인합니다.
+func (app *PoliticianApp) validateProposer(txData *ptypes.TxData) *txError {
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeProposerNotFound, "제안할 계정을 찾을 수 없습니다")
+	}
+	if app.accountAge(account) < app.govParams.MinAccountAge {
+		return newTxError(TxCodeProposerTooNew, "계정이 만들어진 지 얼마 되지 않아 제안할 수 없습니다")
+	}
+	if available := account.USDTBalance - account.EscrowAccount.FrozenUSDTBalance; available < app.govParams.ProposerDeposit {
+		return newTxError(TxCodeNoProposerDeposit, "제안 보증금에 필요한 USDT 잔액이 부족합니다 (필요: "+strconv.FormatInt(app.govParams.ProposerDeposit, 10)+", 사용가능: "+strconv.FormatInt(available, 10)+")")
+	}
+	if txData.TallyMode != "" {
+		if err := txData.TallyMode.Validate(); err != nil {
+			return newTxError(TxCodeInvalidTallyMode, "잘못된 집계 방식입니다: "+err.Error())
+		}
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateProposePolitician(txData *ptypes.TxData) *txError {
+	if err := app.validateProposer(txData); err != nil {
+		return err
+	}
+	if err := ptypes.ValidatePoliticianFields(txData.PoliticianName, txData.Region, txData.Party, txData.IntroUrl); err != nil {
+		return newTxError(TxCodeInvalidPolitician, "정치인 정보가 올바르지 않습니다: "+err.Error())
+	}
+	// 이름과 지역이 같으면 같은 ID가 되므로 이미 등록된 정치인은 다시 발의할 수 없습니다.
+	// 상장 폐지된 정치인도 기록에 남아 ID를 차지하므로 같은 이름과 지역으로 다시 등록할 수 없습니다.
+	// 공백이나 영문 대소문자만 다른 이름, 지역, 정당도 같은 정치인으로 봅니다.
+	id := ptypes.NewPoliticianID(txData.PoliticianName, txData.Region)
+	if _, exists := app.politicianByID(id); exists {
+		return newTxError(TxCodePoliticianExists, "이미 등록된 정치인입니다")
+	}
+	if existing, exists := app.politicianByDedupKey(txData.PoliticianName, txData.Region, txData.Party); exists {
+		return newTxError(TxCodePoliticianExists, "이미 등록된 정치인입니다 ("+existing.String()+")")
+	}
+	if proposal, exists := app.openProposalFor(id, txData.PoliticianName, txData.Region, txData.Party); exists {
+		return newTxError(TxCodeProposalOpen, "이 정치인에 대한 제안이 이미 진행 중입니다 ("+proposal.ID+")")
+	}
+	return nil
+}
+
+// validateProposalTarget은 수정·상장 폐지 제안의 대상 정치인을 ID로 찾습니다.
+func (app *PoliticianApp) validateProposalTarget(id ptypes.PoliticianID) (*ptypes.Politician, *txError) {
+	politician, exists := app.politicianByID(id)
+	if !exists {
+		return nil, newTxError(TxCodeTargetNotFound, "제안 대상 정치인을 찾을 수 없습니다")
+	}
+	if politician.Delisted {
+		return nil, newTxError(TxCodeTargetDelisted, "상장 폐지된 정치인입니다")
+	}
+	return politician, nil
+}
+
+// validateProposeAmendment는 정치인의 지역, 정당, 소개 URL을 바꾸는 제안을 확인합니다.
+// 요청의 지역, 정당, 소개 URL이 그대로 새 정보가 되므로 바꾸지 않을 항목도 현재 값으로 보내야 합니다.
+func (app *PoliticianApp) validateProposeAmendment(txData *ptypes.TxData) *txError {
+	if err := app.validateProposer(txData); err != nil {
+		return err
+	}
+	msg := txData.Msg.ProposeAmendment
+	target, txErr := app.validateProposalTarget(msg.PoliticianID)
+	if txErr != nil {
+		return txErr
+	}
+	if err := ptypes.ValidatePoliticianFields(target.Name, msg.Region, msg.Party, msg.IntroUrl); err != nil {
+		return newTxError(TxCodeInvalidPolitician, "정치인 정보가 올바르지 않습니다: "+err.Error())
+	}
+	amended := amendedPolitician(target, msg)
+	if amended.Region == target.Region && amended.Party == target.Party && amended.IntroUrl == target.IntroUrl {
+		return newTxError(TxCodeAmendmentUnchanged, "수정 제안이 정치인 정보를 바꾸지 않습니다")
+	}
+	if existing, exists := app.politicianByDedupKey(target.Name, amended.Region, amended.Party); exists && existing != target.ID {
+		return newTxError(TxCodePoliticianExists, "이미 등록된 정치인입니다 ("+existing.String()+")")
+	}
+	if proposal, exists := app.openProposalFor(target.ID, target.Name, amended.Region, amended.Party); exists {
+		return newTxError(TxCodeProposalOpen, "이 정치인에 대한 제안이 이미 진행 중입니다 ("+proposal.ID+")")
+	}
+	return nil
+}
+
+// validateProposeDelisting은 정치인을 상장 폐지하는 제안을 확인합니다.
+func (app *PoliticianApp) validateProposeDelisting(txData *ptypes.TxData) *txError {
+	if err := app.validateProposer(txData); err != nil {
+		return err
+	}
+	target, txErr := app.validateProposalTarget(txData.Msg.ProposeDelisting.PoliticianID)
+	if txErr != nil {
+		return txErr
+	}
+	if proposal, exists := app.openProposalFor(target.ID, target.Name, target.Region, target.Party); exists {
+		return newTxError(TxCodeProposalOpen, "이 정치인에 대한 제안이 이미 진행 중입니다 ("+proposal.ID+")")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateVoteOnProposal(txData *ptypes.TxData) *txError {
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeVoterNotFound, "투표할 계정을 찾을 수 없습니다")
+	}
+	proposal, exists := app.proposals[txData.ProposalID]
+	if !exists {
+		return newTxError(TxCodeVoteProposalNotFound, "투표할 제안을 찾을 수 없습니다")
+	}
+	if proposal.Status != ptypes.ProposalStatusVoting {
+		return newTxError(TxCodeVoteProposalClosed, "이미 종료된 제안입니다 ("+proposal.Status+")")
+	}
+	if _, alreadyVoted := proposal.Votes[txData.UserID]; alreadyVoted {
+		return newTxError(TxCodeAlreadyVoted, "이미 투표했습니다")
+	}
+	if proposal.Proposer == txData.UserID {
+		return newTxError(TxCodeProposerVote, "제안자는 자신의 제안에 투표할 수 없습니다")
+	}
+	if app.accountAge(account) < app.govParams.MinAccountAge {
+		return newTxError(TxCodeVoterTooNew, "계정이 만들어진 지 얼마 되지 않아 투표할 수 없습니다")
+	}
+	if app.exec.height > proposal.VotingEndHeight {
+		return newTxError(TxCodeVotingEnded, "투표 기간이 끝났습니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateWithdrawProposal(txData *ptypes.TxData) *txError {
+	proposal, exists := app.proposals[txData.Msg.WithdrawProposal.ProposalID]
+	if !exists {
+		return newTxError(TxCodeWithdrawNotFound, "철회할 제안을 찾을 수 없습니다")
+	}
+	if proposal.Proposer != txData.UserID {
+		return newTxError(TxCodeNotProposer, "제안자만 제안을 철회할 수 있습니다")
+	}
+	if proposal.Status != ptypes.ProposalStatusVoting {
+		return newTxError(TxCodeWithdrawClosed, "이미 종료된 제안입니다 ("+proposal.Status+")")
+	}
+	if app.exec.height > proposal.VotingEndHeight {
+		return newTxError(TxCodeVotingEnded, "투표 기간이 끝났습니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateClaimReferralReward(txData *ptypes.TxData) *txError {
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeReferralAccountNotFound, "추천 보상을 받을 계정을 찾을 수 없습니다")
+	}
+	// 사용 가능한 크레딧이 있는지 확인
+	if account.ReferralCredits <= 0 {
+		return newTxError(TxCodeNoReferralCredits, "사용할 수 있는 추천 크레딧이 없습니다")
+	}
+	// 선택한 정치인이 존재하는지 확인 (정치인 ID 또는 동명이인이 없는 이름)
+	if txData.PoliticianName == "" {
+		return newTxError(TxCodeReferralTargetMissing, "추천 보상을 받을 정치인이 지정되지 않았습니다")
+	}
+	politician, matches := app.resolvePolitician(txData.PoliticianName)
+	if politician == nil {
+		if matches > 1 {
+			return newTxError(TxCodeReferralTargetAmbiguous, "같은 이름의 정치인이 여럿입니다. 정치인 ID를 사용하세요")
+		}
+		return newTxError(TxCodeReferralTargetNotFound, "존재하지 않는 정치인입니다")
+	}
+	if politician.Delisted {
+		return newTxError(TxCodeReferralTargetDelisted, "상장 폐지된 정치인입니다")
+	}
+	// 이미 받은 정치인인지 확인
+	if account.ReceivedCoins[string(politician.ID)] {
+		return newTxError(TxCodeReferralAlreadyClaimed, "이미 이 정치인의 코인을 받았습니다")
+	}
+	if politician.RemainingCoins < 100 {
+		return newTxError(TxCodeReferralSupplyExhausted, "정치인에게 배포할 수 있는 코인이 부족합니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validatePlaceOrder(txData *ptypes.TxData) *txError {
+	msg := txData.Msg.PlaceOrder
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeAccountNotFound, "계정을 찾을 수 없습니다")
+	}
+	politician, exists := app.politicianByID(msg.PoliticianID)
+	if !exists {
+		return newTxError(TxCodePoliticianNotFound, "존재하지 않는 정치인입니다")
+	}
+	if politician.Delisted {
+		return newTxError(TxCodePoliticianDelisted, "상장 폐지된 정치인입니다")
+	}
+	order := newOrderFromMsg(msg)
+	order.UserID = txData.UserID
+	if order.Kind == ptypes.OrderKindMarket {
+		if !app.priceMarketOrder(order) {
+			return newTxError(TxCodeNoLiquidity, "시장가 주문과 체결할 호가가 없습니다")
+		}
+		if order.Price > 0 && order.Quantity > math.MaxInt64/order.Price {
+			return newTxError(TxCodeOrderTooLarge, "주문 금액이 너무 큽니다")
+		}
+	}
+	// 접수되는 블록 끝에서 바로 만료될 주문은 받지 않습니다. 블록 끝의 만료 정리와 같은 규칙입니다.
+	// CheckTx에서는 다음 블록 시간을 모르므로 마지막 블록 시간으로 확인하는 최선의 검사일 뿐이고,
+	// 멤풀을 통과한 주문도 포함된 블록의 시간이 만료 시각에 이르렀으면 FinalizeBlock에서 거부됩니다.
+	if expiresInBlock(order, app.exec.height, app.exec.time) {
+		return newTxError(TxCodeOrderExpired, "만료 높이나 만료 시각이 접수되는 블록 이후여야 합니다 (현재 높이: "+strconv.FormatInt(app.exec.height, 10)+")")
+	}
+	if order.PostOnly && app.crossesBook(order) {
+		return newTxError(TxCodePostOnlyCrosses, "post-only 주문이 오더북의 호가와 바로 체결되는 가격입니다")
+	}
+	if available, required := availableForOrder(account, order), requiredForOrder(order); available < required {
+		return newTxError(TxCodeInsufficientFunds, "사용 가능한 잔액이 부족합니다 (필요: "+strconv.FormatInt(required, 10)+", 사용가능: "+strconv.FormatInt(available, 10)+")")
+	}
+	return nil
+}
+
+// newOrderFromMsg는 접수 요청으로부터 체결 전 상태의 주문을 만듭니다.
+// ID와 시간은 블록 실행 시점에 정해지므로 비워 두고, 시장가 주문의 가격은 priceMarketOrder가 정합니다.
+func newOrderFromMsg(msg *ptypes.PlaceOrderMsg) *ptypes.TradeOrder {
+	kind, timeInForce := ptypes.NormalizeOrderOptions(msg.Kind, msg.TimeInForce)
+	return &ptypes.TradeOrder{
+		PoliticianID:   msg.PoliticianID,
+		OrderType:      msg.OrderType,
+		Currency:       msg.Currency,
+		Quantity:       msg.Quantity,
+		Price:          msg.Price,
+		Kind:           kind,
+		TimeInForce:    timeInForce,
+		PostOnly:       msg.PostOnly,
+		MaxSlippageBps: msg.MaxSlippageBps,
+		ExpiresHeight:  msg.ExpiresHeight,
+		ExpiresAt:      msg.ExpiresAt,
+	}
+}
+
+func (app *PoliticianApp) validateCancelOrder(txData *ptypes.TxData) *txError {
+	order, exists := app.orders[txData.Msg.CancelOrder.OrderID]
+	if !exists {
+		return newTxError(TxCodeOrderNotFound, "주문을 찾을 수 없습니다")
+	}
+	// 주문 소유권 확인
+	if order.UserID != txData.UserID {
+		return newTxError(TxCodeNotOrderOwner, "주문을 취소할 권한이 없습니다")
+	}
+	if !isOpenOrder(order) {
+		return newTxError(TxCodeOrderClosed, "이미 처리된 주문입니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateReleaseEscrow(txData *ptypes.TxData) *txError {
+	order, exists := app.orders[txData.Msg.ReleaseEscrow.OrderID]
+	if !exists {
+		return newTxError(TxCodeOrderNotFound, "주문을 찾을 수 없습니다")
+	}
+	if _, exists := app.accounts[txData.UserID]; !exists {
+		return newTxError(TxCodeAccountNotFound, "계정을 찾을 수 없습니다")
+	}
+	if order.UserID != txData.UserID {
+		return newTxError(TxCodeNotOrderOwner, "에스크로를 해제할 권한이 없습니다")
+	}
+	// 체결 대기 중인 주문의 에스크로는 해제할 수 없음
+	if isOpenOrder(order) {
+		return newTxError(TxCodeOrderOpen, "미체결 주문의 에스크로는 해제할 수 없습니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateDepositStablecoin(txData *ptypes.TxData) *txError {
+	if _, exists := app.accounts[txData.UserID]; !exists {
+		return newTxError(TxCodeAccountNotFound, "계정을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+func (app *PoliticianApp) validateWithdrawStablecoin(txData *ptypes.TxData) *txError {
+	withdrawal := txData.Msg.Withdraw
+	account, exists := app.accounts[txData.UserID]
+	if !exists {
+		return newTxError(TxCodeAccountNotFound, "계정을 찾을 수 없습니다")
+	}
+	switch withdrawal.Token {
+	case "USDT":
+		if account.USDTBalance-account.EscrowAccount.FrozenUSDTBalance < withdrawal.Amount {
+			return newTxError(TxCodeInsufficientFunds, "사용 가능한 USDT 잔액이 부족합니다")
+		}
+	case "USDC":
+		if account.USDCBalance-account.EscrowAccount.FrozenUSDCBalance < withdrawal.Amount {
+			return newTxError(TxCodeInsufficientFunds, "사용 가능한 USDC 잔액이 부족합니다")
+		}
+	}
+	return nil
+}
+
+// requiredForOrder는 주문의 미체결 부분을 위해 필요한 금액을 반환합니다.
+// 매수는 스테이블코인(수량 × 가격), 매도는 정치인 코인 수량입니다.
+func requiredForOrder(order *ptypes.TradeOrder) int64 {
+	remaining := remainingQuantity(order)
+	if order.OrderType == "buy" {
+		return remaining * order.Price
+	}
+	return remaining
+}
+
+// availableForOrder는 주문에 사용할 수 있는(동결되지 않은) 잔액을 반환합니다.
+func availableForOrder(account *ptypes.Account, order *ptypes.TradeOrder) int64 {
+	if order.OrderType == "buy" {
+		return *quoteBalance(account, order.Currency) - *frozenQuoteBalance(account, order.Currency)
+	}
+	politicianID := string(order.PoliticianID)
+	return account.PoliticianCoins[politicianID] - account.EscrowAccount.FrozenPoliticianCoins[politicianID]
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// parityChain은 정치인 한 명, 잔액과 코인이 있는 alice, 빈 계정 bob이 있고
+// 만들어진 뒤 2블록이 지나야 제안할 수 있는 테스트 체인을 만듭니다.
+func parityChain(t *testing.T) (*testChain, ptypes.PoliticianID) {
+	t.Helper()
+	politician := &ptypes.Politician{Name: "홍길동", Region: "서울"}
+	roster, err := ptypes.NewPoliticianRoster([]*ptypes.Politician{politician})
+	if err != nil {
+		t.Fatal(err)
+	}
+	politician.RemainingCoins -= 10
+	politician.DistributedCoins = 10
+	return newTestChain(t, ptypes.GenesisState{
+		Politicians: roster,
+		Accounts: map[string]*ptypes.Account{
+			"alice": {Address: "alice", USDTBalance: 1_000, PoliticianCoins: map[string]int64{string(politician.ID): 10}},
+			"bob":   {Address: "bob"},
+		},
+		GovParams: &ptypes.GovParams{Quorum: 1, ApprovalThreshold: 50, VotingPeriod: 10, MinAccountAge: 2, ProposerDeposit: 100},
+	}), politician.ID
+}
+
+// CheckTx는 트랜잭션이 다음 블록에 들어간다고 보고 검증하므로, 같은 상태에서 FinalizeBlock과 같은 코드를 반환합니다.
+func TestCheckTxMatchesFinalizeBlock(t *testing.T) {
+	sell := func(id ptypes.PoliticianID) ptypes.TxData {
+		return placeOrder("alice", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 1, Price: 100})
+	}
+	tests := []struct {
+		name string
+		// tx는 필요한 블록을 먼저 실행한 뒤 검사할 트랜잭션 바이트를 만듭니다.
+		tx       func(c *testChain, id ptypes.PoliticianID) []byte
+		wantCode uint32
+	}{
+		{name: "undecodable bytes", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return []byte("not a transaction")
+		}, wantCode: TxCodeDecodeFailed},
+		{name: "unknown action", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return c.sign(ptypes.TxData{Action: "mint", UserID: "alice"})
+		}, wantCode: TxCodeUnknownAction},
+		{name: "missing message", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return c.sign(ptypes.TxData{Action: "place_order", UserID: "alice"})
+		}, wantCode: TxCodeInvalidMsg},
+		{name: "signed by another key", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			return signWith(t, ed25519.GenPrivKeyFromSecret([]byte("mallory")), 1, sell(id))
+		}, wantCode: TxCodeUnauthorized},
+		{name: "unknown politician", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return c.sign(sell("없는-정치인"))
+		}, wantCode: TxCodePoliticianNotFound},
+		{name: "insufficient coins", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			return c.sign(placeOrder("bob", ptypes.PlaceOrderMsg{PoliticianID: id, OrderType: "sell", Currency: "USDT", Quantity: 1, Price: 100}))
+		}, wantCode: TxCodeInsufficientFunds},
+		{name: "expires in the including block", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			c.block()
+			tx := sell(id)
+			tx.Msg.PlaceOrder.ExpiresHeight = c.height + 1
+			return c.sign(tx)
+		}, wantCode: TxCodeOrderExpired},
+		{name: "expires after the including block", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			c.block()
+			tx := sell(id)
+			tx.Msg.PlaceOrder.ExpiresHeight = c.height + 2
+			return c.sign(tx)
+		}},
+		{name: "unknown order", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return c.sign(ptypes.TxData{Action: "cancel_order", UserID: "alice", Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: "order_0"}}})
+		}, wantCode: TxCodeOrderNotFound},
+		{name: "another account's order", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			orderID := string(c.block(sell(id))[0].Data)
+			return c.sign(ptypes.TxData{Action: "cancel_order", UserID: "bob", Msg: &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: orderID}}})
+		}, wantCode: TxCodeNotOrderOwner},
+		{name: "withdrawal above the available balance", tx: func(c *testChain, _ ptypes.PoliticianID) []byte {
+			return c.sign(ptypes.TxData{Action: "withdraw_stablecoin", UserID: "alice", Msg: &ptypes.TxMsg{Withdraw: &ptypes.WithdrawMsg{Token: "USDT", Amount: 1_001, ToAddress: "0xabc"}}})
+		}, wantCode: TxCodeInsufficientFunds},
+		{name: "proposer one block too new", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			return c.sign(ptypes.TxData{Action: "propose_delisting", UserID: "alice", Msg: &ptypes.TxMsg{ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: id}}})
+		}, wantCode: TxCodeProposerTooNew},
+		{name: "proposer old enough", tx: func(c *testChain, id ptypes.PoliticianID) []byte {
+			c.block()
+			return c.sign(ptypes.TxData{Action: "propose_delisting", UserID: "alice", Msg: &ptypes.TxMsg{ProposeDelisting: &ptypes.ProposeDelistingMsg{PoliticianID: id}}})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, id := parityChain(t)
+			raw := tt.tx(chain, id)
+			check, err := chain.app.CheckTx(context.Background(), &types.RequestCheckTx{Tx: raw})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if check.Code != tt.wantCode {
+				t.Fatalf("CheckTx code %d %s, want %d", check.Code, check.Log, tt.wantCode)
+			}
+			// 거부 메시지도 같아야 합니다. 성공한 실행의 Log는 CheckTx에 없는 실행 결과(주문 상태 등)입니다.
+			res := chain.execRaw(raw)[0]
+			if res.Code != check.Code || (tt.wantCode != 0 && res.Log != check.Log) {
+				t.Fatalf("FinalizeBlock code %d %s, CheckTx code %d %s", res.Code, res.Log, check.Code, check.Log)
+			}
+		})
+	}
+}
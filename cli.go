@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/types"
+	"github.com/jclee286/politisian/app"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+	"github.com/jclee286/politisian/server"
+)
+
+// runExportGenesis는 --height 시점의 애플리케이션 상태를 담은 genesis.json을 씁니다.
+// 새 제네시스는 기존 제네시스의 검증자와 합의 파라미터를 그대로 쓰고, 내보낸 높이 다음부터 시작합니다.
+// 애플리케이션 DB를 직접 열기 때문에 노드를 멈춘 뒤 실행해야 합니다.
+//
+//	politisian export-genesis --height 1200 --output genesis.json
+func runExportGenesis(args []string) error {
+	c := newCLIConfig(flag.NewFlagSet("export-genesis", flag.ContinueOnError))
+	output := c.fs.String("output", "", "file to write the genesis to (default stdout)")
+	height := c.fs.Int64("height", 0, "height to export; earlier than the last block requires a snapshot at that height (default last block)")
+	chainID := c.fs.String("chain-id", "", "chain ID of the exported genesis (default unchanged)")
+	if err := c.parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfigFile(c.Home)
+	if err != nil {
+		return err
+	}
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	if err != nil {
+		return fmt.Errorf("failed to read genesis: %w", err)
+	}
+
+	db, err := dbm.NewDB("politisian_app", dbm.GoLevelDBBackend, cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("failed to open application db (is the node still running?): %w", err)
+	}
+	defer db.Close()
+	abciApp := app.NewPoliticianApp(db, log.NewNopLogger())
+	if err := abciApp.EnableSnapshots(app.SnapshotOptions{Dir: filepath.Join(cfg.DBDir(), "snapshots")}); err != nil {
+		return err
+	}
+	state, exportedHeight, err := abciApp.ExportGenesis(*height)
+	if err != nil {
+		return err
+	}
+	appState, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal app state: %w", err)
+	}
+
+	genDoc.AppState = appState
+	genDoc.InitialHeight = exportedHeight + 1
+	genDoc.GenesisTime = time.Now()
+	genDoc.AppHash = nil
+	if *chainID != "" {
+		genDoc.ChainID = *chainID
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return fmt.Errorf("invalid exported genesis: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported state at height %d\n", exportedHeight)
+	return writeGenesis(genDoc, *output)
+}
+
+// writeGenesis는 제네시스 문서를 path(빈 값이면 표준 출력)에 씁니다.
+func writeGenesis(genDoc *types.GenesisDoc, path string) error {
+	if path != "" {
+		return genDoc.SaveAs(path)
+	}
+	bz, err := cmtjson.MarshalIndent(genDoc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(bz, '\n'))
+	return err
+}
+
+// runQuery는 실행 중인 노드에 ABCI 쿼리를 보내고 결과 JSON을 출력합니다.
+//
+//	politisian query account <address>
+//	politisian query politician <id | name>
+//	politisian query politicians [--name <name>] [--region <region>]
+//	politisian query params
+//	politisian query orders --politician <id> | --user <id> [--status active]
+func runQuery(args []string) error {
+	c := newCLIConfig(flag.NewFlagSet("query", flag.ContinueOnError))
+	c.registerClientFlags()
+	politicianID := c.fs.String("politician", "", "orders: politician ID")
+	userID := c.fs.String("user", "", "orders: owner user ID")
+	status := c.fs.String("status", "", "orders, proposals: filter by status")
+	limit := c.fs.Int("limit", 0, "politicians, proposals, orders: maximum number of results")
+	offset := c.fs.Int("offset", 0, "politicians, proposals, orders: number of results to skip")
+	name := c.fs.String("name", "", "politicians: filter by name")
+	region := c.fs.String("region", "", "politicians: filter by region")
+	if len(args) == 0 {
+		return fmt.Errorf("usage: politisian query account <address> | politician <id> | politicians | proposals | params | orders [flags]")
+	}
+	what, args := args[0], args[1:]
+	// 위치 인자 뒤에 오는 플래그도 받을 수 있도록 ID를 먼저 분리합니다.
+	var id string
+	if (what == "account" || what == "politician") && len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		id, args = args[0], args[1:]
+	}
+	if err := c.parse(args); err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	var path string
+	switch what {
+	case "account":
+		path = "/account"
+		params.Set("address", id)
+	case "politician":
+		path = "/politician"
+		params.Set("id", id)
+	case "params":
+		path = "/params/governance"
+	case "proposals":
+		path = "/proposals/list"
+		if *status != "" {
+			params.Set("status", *status)
+		}
+	case "politicians":
+		path = "/politicians"
+		if *name != "" {
+			params.Set("name", *name)
+		}
+		if *region != "" {
+			params.Set("region", *region)
+		}
+	case "orders":
+		path = "/orders"
+		params.Set("politician_id", *politicianID)
+		if *userID != "" {
+			path = "/user-orders"
+			params.Del("politician_id")
+			params.Set("user_id", *userID)
+		}
+		if *status != "" {
+			params.Set("status", *status)
+		}
+	default:
+		return fmt.Errorf("unknown query %q (expected account, politician, politicians, proposals, params or orders)", what)
+	}
+
+	if *limit > 0 {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if *offset > 0 {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+
+	client, err := rpchttp.New(c.Node, "/websocket")
+	if err != nil {
+		return fmt.Errorf("failed to create RPC client: %w", err)
+	}
+	res, err := client.ABCIQuery(context.Background(), path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	if res.Response.Code != 0 {
+		return fmt.Errorf("query failed: %s (code %d)", res.Response.Log, res.Response.Code)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, res.Response.Value, "", "  "); err != nil {
+		out.Reset()
+		out.Write(res.Response.Value)
+	}
+	fmt.Println(out.String())
+	return nil
+}
+
+// runRegistrarKey는 TX_SIGNING_SECRET에서 파생한 키 등록 공개키를 16진수로 출력합니다.
+// 웹 서버와 같은 비밀값으로 실행해 얻은 값을 init, start, testnet의 --key-registrar로 넘깁니다.
+func runRegistrarKey(args []string) error {
+	fs := flag.NewFlagSet("registrar-key", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := server.LoadTxSigningSecret(); err != nil {
+		return err
+	}
+	fmt.Printf("%X\n", server.RegistrarPubKey())
+	return nil
+}
+
+// runTx는 TxData를 서명해 제출하고, 블록에 포함될 때까지 기다린 뒤 결과를 출력합니다.
+// 서명키는 서버와 같이 TX_SIGNING_SECRET에서 파생되며(server.SigningKeyFor), 체인 ID는 노드에서 읽고,
+// nonce는 체인의 계정 nonce 다음 값을 사용합니다.
+//
+//	politisian tx --user alice --action place_order --data '{"msg":{"place_order":{...}}}'
+func runTx(args []string) error {
+	c := newCLIConfig(flag.NewFlagSet("tx", flag.ContinueOnError))
+	c.registerClientFlags()
+	userID := c.fs.String("user", "", "user ID that signs the transaction")
+	action := c.fs.String("action", "", "transaction action, e.g. place_order")
+	data := c.fs.String("data", "", "other TxData fields as JSON")
+	if err := c.parse(args); err != nil {
+		return err
+	}
+	if *userID == "" || *action == "" {
+		return fmt.Errorf("--user and --action are required")
+	}
+	if err := server.LoadTxSigningSecret(); err != nil {
+		return err
+	}
+
+	var txData ptypes.TxData
+	if *data != "" {
+		if err := json.Unmarshal([]byte(*data), &txData); err != nil {
+			return fmt.Errorf("invalid --data: %w", err)
+		}
+	}
+	txData.UserID = *userID
+	txData.Action = *action
+
+	client, err := rpchttp.New(c.Node, "/websocket")
+	if err != nil {
+		return fmt.Errorf("failed to create RPC client: %w", err)
+	}
+	ctx := context.Background()
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("status query failed: %w", err)
+	}
+
+	var nonce uint64
+	res, err := client.ABCIQuery(ctx, "/account?address="+url.QueryEscape(*userID), nil)
+	if err != nil {
+		return fmt.Errorf("account query failed: %w", err)
+	}
+	if res.Response.Code == 0 {
+		var account ptypes.Account
+		if err := json.Unmarshal(res.Response.Value, &account); err != nil {
+			return fmt.Errorf("failed to decode account: %w", err)
+		}
+		nonce = account.Nonce
+	}
+
+	signed, err := ptypes.SignTx(server.SigningKeyFor(&txData), status.NodeInfo.Network, nonce+1, &txData)
+	if err != nil {
+		return err
+	}
+	result, err := client.BroadcastTxCommit(ctx, ptypes.MarshalSignedTx(signed))
+	if err != nil {
+		return fmt.Errorf("broadcast failed: %w", err)
+	}
+	if result.CheckTx.Code != 0 {
+		return fmt.Errorf("rejected by CheckTx: %s (code %d)", result.CheckTx.Log, result.CheckTx.Code)
+	}
+	fmt.Printf("hash: %s\nheight: %d\ncode: %d\n", result.Hash, result.Height, result.TxResult.Code)
+	if result.TxResult.Log != "" {
+		fmt.Printf("log: %s\n", result.TxResult.Log)
+	}
+	if len(result.TxResult.Data) > 0 {
+		fmt.Printf("data: %s\n", result.TxResult.Data)
+	}
+	if result.TxResult.Code != 0 {
+		return fmt.Errorf("transaction failed with code %d", result.TxResult.Code)
+	}
+	return nil
+}
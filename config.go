@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/spf13/viper"
+)
+
+// cliConfig는 명령줄 플래그와 설정 파일에서 읽은 값입니다.
+//
+// 우선순위는 플래그 > 설정 파일(--config, 기본 ~/politisian/politisian.toml) > 환경 변수 > 기본값입니다.
+// 설정 파일의 키는 플래그 이름과 같습니다.
+//
+//	home = "/data/politisian/.cometbft"
+//	chain-id = "politisian-chain-1"
+//	rpc-laddr = "tcp://0.0.0.0:26657"
+//	timeout-commit = "5s"
+//	key-registrar = "8F3C...E1"
+type cliConfig struct {
+	Home       string
+	ConfigFile string
+
+	// 노드 설정 (init, start)
+	ChainID            string
+	Moniker            string
+	RPCAddr            string
+	P2PAddr            string
+	HTTPPort           string
+	TimeoutPropose     time.Duration
+	TimeoutCommit      time.Duration
+	SnapshotInterval   uint64
+	SnapshotKeepRecent int
+	Roster             string
+	KeyRegistrar       string
+
+	// 클라이언트 설정 (query, tx)
+	Node string
+
+	fs  *flag.FlagSet
+	set map[string]bool // 플래그나 설정 파일로 명시된 설정
+}
+
+// newCLIConfig는 모든 명령이 공통으로 쓰는 --home, --config 플래그를 등록합니다.
+func newCLIConfig(fs *flag.FlagSet) *cliConfig {
+	c := &cliConfig{fs: fs, set: make(map[string]bool)}
+	fs.StringVar(&c.Home, "home", defaultHome(), "CometBFT home directory")
+	fs.StringVar(&c.ConfigFile, "config", "", "settings file (default ~/politisian/politisian.toml if it exists)")
+	return c
+}
+
+// registerNodeFlags는 노드를 만들고 실행하는 명령의 플래그를 등록합니다.
+func (c *cliConfig) registerNodeFlags() {
+	def := config.DefaultConfig()
+	c.fs.StringVar(&c.ChainID, "chain-id", defaultChainID, "chain ID written to a new genesis")
+	c.fs.StringVar(&c.Moniker, "moniker", def.Moniker, "node name")
+	c.fs.StringVar(&c.RPCAddr, "rpc-laddr", "tcp://0.0.0.0:26657", "CometBFT RPC listen address")
+	c.fs.StringVar(&c.P2PAddr, "p2p-laddr", def.P2P.ListenAddress, "CometBFT P2P listen address")
+	c.fs.StringVar(&c.HTTPPort, "http-port", envOr("PORT", "8080"), "HTTP API server port")
+	c.fs.DurationVar(&c.TimeoutPropose, "timeout-propose", def.Consensus.TimeoutPropose, "consensus propose timeout")
+	c.fs.DurationVar(&c.TimeoutCommit, "timeout-commit", 5*time.Second, "consensus commit timeout")
+	c.fs.Uint64Var(&c.SnapshotInterval, "snapshot-interval", 1000, "create a state sync snapshot every N blocks (0 disables) [env SNAPSHOT_INTERVAL]")
+	c.fs.IntVar(&c.SnapshotKeepRecent, "snapshot-keep-recent", 2, "number of recent snapshots to keep [env SNAPSHOT_KEEP_RECENT]")
+	c.fs.StringVar(&c.Roster, "roster", "", "politician roster (.csv or .json) written to a new genesis (default built-in roster)")
+	c.fs.StringVar(&c.KeyRegistrar, "key-registrar", "", "hex ed25519 public key of the key registrar written to a new genesis; print it with 'politisian registrar-key' [env KEY_REGISTRAR]")
+}
+
+// registerClientFlags는 실행 중인 노드에 접속하는 명령의 플래그를 등록합니다.
+func (c *cliConfig) registerClientFlags() {
+	c.fs.StringVar(&c.Node, "node", "tcp://127.0.0.1:26657", "CometBFT RPC address of the node")
+}
+
+// parse는 플래그를 해석한 뒤, 명령줄에서 지정하지 않은 설정을 설정 파일과 환경 변수에서 채웁니다.
+func (c *cliConfig) parse(args []string) error {
+	if err := c.fs.Parse(args); err != nil {
+		return err
+	}
+	c.fs.Visit(func(f *flag.Flag) { c.set[f.Name] = true })
+
+	file, explicit := c.ConfigFile, c.ConfigFile != ""
+	if !explicit {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			file = filepath.Join(homeDir, "politisian", "politisian.toml")
+		}
+	}
+	if file != "" {
+		if _, err := os.Stat(file); err == nil || explicit {
+			v := viper.New()
+			v.SetConfigFile(file)
+			v.SetConfigType("toml")
+			if err := v.ReadInConfig(); err != nil {
+				return fmt.Errorf("failed to read settings file %s: %w", file, err)
+			}
+			var err error
+			c.fs.VisitAll(func(f *flag.Flag) {
+				if err != nil || c.set[f.Name] || !v.IsSet(f.Name) {
+					return
+				}
+				if err = c.fs.Set(f.Name, v.GetString(f.Name)); err != nil {
+					err = fmt.Errorf("%s: invalid %s: %w", file, f.Name, err)
+				}
+				c.set[f.Name] = true
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// 이전 버전과의 호환을 위해 스냅샷 설정은 환경 변수로도 받습니다. 키 등록 공개키도 컨테이너에서 넘기기 쉽도록 환경 변수로 받습니다.
+	for name, env := range map[string]string{"snapshot-interval": "SNAPSHOT_INTERVAL", "snapshot-keep-recent": "SNAPSHOT_KEEP_RECENT", "key-registrar": "KEY_REGISTRAR"} {
+		if value := os.Getenv(env); value != "" && c.fs.Lookup(name) != nil && !c.set[name] {
+			if err := c.fs.Set(name, value); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", env, value, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyNodeConfig는 노드 설정을 CometBFT 설정에 반영합니다.
+// onlySet이면 플래그나 설정 파일로 명시된 값만 반영해, 기존 config.toml의 값을 유지합니다.
+func (c *cliConfig) applyNodeConfig(cfg *config.Config, onlySet bool) {
+	apply := func(name string, fn func()) {
+		if !onlySet || c.set[name] {
+			fn()
+		}
+	}
+	apply("moniker", func() { cfg.Moniker = c.Moniker })
+	apply("rpc-laddr", func() { cfg.RPC.ListenAddress = c.RPCAddr })
+	apply("p2p-laddr", func() { cfg.P2P.ListenAddress = c.P2PAddr })
+	apply("timeout-propose", func() { cfg.Consensus.TimeoutPropose = c.TimeoutPropose })
+	apply("timeout-commit", func() { cfg.Consensus.TimeoutCommit = c.TimeoutCommit })
+}
+
+func defaultHome() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join("politisian", ".cometbft")
+	}
+	return filepath.Join(homeDir, "politisian", ".cometbft")
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -1,10 +1,23 @@
 // 정치인 관련 기능들
 
+// 제안 집계 방식 표시 이름
+const TALLY_MODE_LABELS = {
+    one_account_one_vote: '계정당 1표',
+    coin_weighted: '코인 가중',
+    quadratic: '이차 투표'
+};
+
+// 제안 종류 표시 이름 (등록 제안은 표시하지 않음)
+const PROPOSAL_TYPE_LABELS = {
+    amend: '<span class="text-xs text-blue-600">[정보 수정]</span>',
+    delist: '<span class="text-xs text-red-600">[상장 폐지]</span>'
+};
+
 // 제안 목록 로드
 function loadProposals() {
     console.log('📋 제안 목록 로드 시작');
     
-    fetch('/api/politisian/list')
+    fetch('/api/politisian/list?status=voting')
         .then(response => {
             if (!response.ok) {
                 throw new Error(`API 오류: ${response.status}`);
@@ -40,9 +53,10 @@ function loadProposals() {
                 li.innerHTML = `
                     <div style="display: flex; justify-content: space-between; align-items: center;">
                         <div>
-                            <strong>${proposal.politician.name}</strong> (${proposal.politician.party})
+                            <strong>${proposal.politician.name}</strong> (${proposal.politician.party}) ${PROPOSAL_TYPE_LABELS[proposal.type] || ''}
                             <br><small>지역: ${proposal.politician.region || '미정'}</small>
                             <br><small>찬성: ${proposal.yes_votes || 0}표, 반대: ${proposal.no_votes || 0}표</small>
+                            <br><small>투표 마감: ${proposal.voting_end_height}번 블록 · 집계: ${TALLY_MODE_LABELS[proposal.tally_mode] || '계정당 1표'}</small>
                         </div>
                         <div>
                             <button class="button vote-button approve" onclick="voteOnProposal('${proposal.id}', true)">
@@ -205,6 +219,7 @@ function handleProposeSubmit(event) {
     const region = document.getElementById('region').value.trim();
     const party = document.getElementById('party').value.trim();
     const introUrl = document.getElementById('intro-url').value.trim();
+    const tallyModeSelect = document.getElementById('tally-mode');
     
     if (!name || !party) {
         showToast('이름과 정당은 필수 입력사항입니다.', 'error');
@@ -215,7 +230,8 @@ function handleProposeSubmit(event) {
         name: name,
         region: region || '',
         party: party,
-        introUrl: introUrl || ''
+        introUrl: introUrl || '',
+        tallyMode: tallyModeSelect ? tallyModeSelect.value : ''
     };
     
     console.log('새 정치인 제안 데이터:', proposalData);
@@ -1,5 +1,15 @@
 // 거래 관련 기능들
 
+// 주문 상태 표시 이름
+const ORDER_STATUS_LABELS = {
+    active: '대기중',
+    partial: '부분 체결',
+    filled: '체결 완료',
+    cancelled: '취소됨',
+    killed: '미체결 종료 (FOK)',
+    expired: '기간 만료'
+};
+
 // 거래 데이터 로드
 function loadTradingData() {
     console.log('📊 거래 데이터 로드 시작');
@@ -8,11 +18,12 @@ function loadTradingData() {
     loadPoliticianSelectOptions();
 }
 
-// 정치인 가격 정보 로드
+// 정치인 가격 정보 로드 (선택한 결제 통화의 오더북 기준)
 function loadPoliticianPrices() {
     console.log('💰 정치인 가격 정보 로드 시작');
+    const currency = document.querySelector('input[name="currency"]:checked')?.value || 'USDT';
     
-    fetch('/api/trading/prices')
+    fetch(`/api/trading/prices?currency=${encodeURIComponent(currency)}`)
         .then(response => {
             if (!response.ok) {
                 // 가격 정보는 선택적 기능이므로 에러를 조용히 처리
@@ -161,12 +172,13 @@ function displayMyOrders(orders) {
                 <div>
                     <strong>${typeIcon} ${typeText}</strong> - ${order.politician_name || order.politician}
                     <br><small>수량: ${order.quantity}개 | 가격: ${order.price} ${order.currency || 'USDT'}</small>
-                    <br><small>상태: ${order.status || '대기중'}</small>
+                    <br><small>상태: ${ORDER_STATUS_LABELS[order.status] || order.status || '대기중'}${order.filled_quantity ? ` (${order.filled_quantity}개 체결)` : ''}</small>
                 </div>
+                ${order.status === 'active' || order.status === 'partial' ? `
                 <button onclick="cancelOrder('${order.id}')" 
                         style="background: #dc3545; color: white; border: none; padding: 5px 10px; border-radius: 4px; cursor: pointer;">
                     취소
-                </button>
+                </button>` : ''}
             </div>
         `;
         
@@ -185,14 +197,25 @@ function updateTradeSummary() {
     const summaryElem = document.getElementById('trade-summary');
     if (!summaryElem) return;
     
+    const mode = getTradeOrderMode();
+    const politicianName = getPoliticianNameById(politician);
+    const typeText = orderType === 'buy' ? '구매' : '판매';
+    
+    if (mode.kind === 'market' && politician && quantity > 0) {
+        const slippagePercent = parseFloat(document.getElementById('trade-slippage')?.value) || 0;
+        summaryElem.innerHTML = `
+            <strong>${typeText}:</strong> ${politicianName} ${quantity}개<br>
+            <strong>시장가 ${mode.timeInForce}:</strong> 최우선 호가 대비 최대 ${slippagePercent}% 슬리피지 (${currency})
+        `;
+        return;
+    }
+    
     if (!politician || quantity <= 0 || price <= 0) {
         summaryElem.textContent = '정치인과 수량, 가격을 선택하세요';
         return;
     }
     
     const total = quantity * price;
-    const politicianName = getPoliticianNameById(politician);
-    const typeText = orderType === 'buy' ? '구매' : '판매';
     
     summaryElem.innerHTML = `
         <strong>${typeText}:</strong> ${politicianName} ${quantity}개<br>
@@ -201,6 +224,13 @@ function updateTradeSummary() {
     `;
 }
 
+// 주문 방식 선택값("limit:GTC", "market:IOC", "limit:post_only" 등)을 주문 옵션으로 변환
+function getTradeOrderMode() {
+    const [kind, option] = (document.getElementById('trade-order-mode')?.value || 'limit:GTC').split(':');
+    const postOnly = option === 'post_only';
+    return { kind: kind, timeInForce: postOnly ? 'GTC' : option, postOnly: postOnly };
+}
+
 // 거래 주문 등록
 function placeTradeOrder() {
     const politician = document.getElementById('trade-politician').value;
@@ -208,6 +238,8 @@ function placeTradeOrder() {
     const price = parseFloat(document.getElementById('trade-price').value);
     const orderType = document.querySelector('input[name="order-type"]:checked')?.value;
     const currency = document.querySelector('input[name="currency"]:checked')?.value || 'USDT';
+    const mode = getTradeOrderMode();
+    const isMarket = mode.kind === 'market';
     
     // 입력값 검증
     if (!politician) {
@@ -220,11 +252,20 @@ function placeTradeOrder() {
         return;
     }
     
-    if (!price || price <= 0) {
+    if (!isMarket && (!price || price <= 0)) {
         showToast('올바른 가격을 입력해주세요.', 'error');
         return;
     }
     
+    // 유효 기간은 오더북에 남는 지정가 GTC 주문에만 적용됩니다.
+    const expirySeconds = mode.timeInForce === 'GTC' ? parseInt(document.getElementById('trade-expiry')?.value) || 0 : 0;
+    
+    const slippagePercent = parseFloat(document.getElementById('trade-slippage')?.value) || 0;
+    if (isMarket && (slippagePercent < 0 || slippagePercent > 100)) {
+        showToast('최대 슬리피지는 0~100% 사이여야 합니다.', 'error');
+        return;
+    }
+    
     if (!orderType) {
         showToast('주문 유형을 선택해주세요.', 'error');
         return;
@@ -233,9 +274,14 @@ function placeTradeOrder() {
     const orderData = {
         politician: politician,
         quantity: quantity,
-        price: price,
+        price: isMarket ? 0 : price,
         order_type: orderType,
-        currency: currency
+        currency: currency,
+        kind: mode.kind,
+        time_in_force: mode.timeInForce,
+        post_only: mode.postOnly,
+        max_slippage_bps: isMarket ? Math.round(slippagePercent * 100) : 0,
+        expires_at: expirySeconds > 0 ? Math.floor(Date.now() / 1000) + expirySeconds : 0
     };
     
     console.log('거래 주문 데이터:', orderData);
@@ -347,7 +393,7 @@ function setupTradingEvents() {
     }
     
     // 거래 요약 자동 업데이트
-    const tradeInputs = ['trade-politician', 'trade-quantity', 'trade-price'];
+    const tradeInputs = ['trade-politician', 'trade-quantity', 'trade-price', 'trade-order-mode', 'trade-slippage'];
     tradeInputs.forEach(id => {
         const elem = document.getElementById(id);
         if (elem) {
@@ -361,4 +407,9 @@ function setupTradingEvents() {
     radioButtons.forEach(radio => {
         radio.addEventListener('change', updateTradeSummary);
     });
+
+    // 결제 통화가 바뀌면 해당 통화의 가격 정보를 다시 로드
+    document.querySelectorAll('input[name="currency"]').forEach(radio => {
+        radio.addEventListener('change', loadPoliticianPrices);
+    });
 }
\ No newline at end of file
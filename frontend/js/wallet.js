@@ -213,14 +213,17 @@ function loadAvailablePoliticiansForCredit() {
     fetch('/api/politisian/registered')
         .then(response => response.json())
         .then(politicians => {
-            if (politicians && Array.isArray(politicians)) {
+            if (politicians) {
                 const userProfile = getCurrentUserProfile();
                 const alreadySelected = userProfile ? userProfile.politicians : [];
+                // 체인은 정치인 ID를 키로 하는 객체를 반환하고, 계정의 지지 목록도 정치인 ID입니다.
+                const list = Array.isArray(politicians) ? politicians : Object.values(politicians);
                 
-                politicians.forEach(politician => {
-                    if (!alreadySelected.includes(politician.name)) {
+                list.forEach(politician => {
+                    const id = politician.id || politician.name;
+                    if (!alreadySelected.includes(id)) {
                         const option = document.createElement('option');
-                        option.value = politician.name;
+                        option.value = id;
                         option.textContent = `${politician.name} (${politician.party})`;
                         select.appendChild(option);
                     }
@@ -1,10 +1,15 @@
 package main
 
 import (
+	_ "embed"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/jclee286/politisian/app"
@@ -12,6 +17,7 @@ import (
 	"github.com/jclee286/politisian/server"
 
 	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/node"
@@ -19,46 +25,125 @@ import (
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
+	"github.com/spf13/viper"
 )
 
+// defaultChainID는 단일 노드 실행과 testnet이 기본으로 사용하는 체인 ID입니다.
+const defaultChainID = "politisian-chain-1"
+
+// defaultRoster는 --roster를 지정하지 않았을 때 새 제네시스에 넣는 정치인 목록입니다.
+//
+//go:embed genesis/politicians.csv
+var defaultRoster []byte
+
+// command는 politisian 바이너리의 하위 명령입니다.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"init", "create config, keys and genesis in the home directory", runInit},
+	{"start", "run the node and the HTTP API server (default)", runStart},
+	{"export-genesis", "write the node's genesis.json", runExportGenesis},
+	{"query", "query a running node: account <address> | politician <id> | politicians | proposals | params | orders", runQuery},
+	{"tx", "sign and broadcast a transaction", runTx},
+	{"registrar-key", "print the key registrar public key derived from TX_SIGNING_SECRET", runRegistrarKey},
+	{"testnet", "generate node homes for a local multi-validator network", runTestnet},
+}
+
 func main() {
-	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	// 인자가 없거나 플래그로 시작하면 이전처럼 노드를 실행합니다.
+	name, args := "start", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(args); err != nil {
+				if errors.Is(err, flag.ErrHelp) {
+					return
+				}
+				fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	if name != "help" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	}
+	fmt.Fprintln(os.Stderr, "Usage: politisian <command> [flags]\n\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-15s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun 'politisian <command> -h' for the flags of a command.")
+	if name != "help" {
+		os.Exit(2)
+	}
+}
 
-	if err := run(logger); err != nil {
-		logger.Error("Failed to run application", "error", err)
-		os.Exit(1)
+// runInit은 홈 디렉터리에 config.toml, 검증자/노드 키, genesis.json을 만듭니다. 이미 있는 파일은 그대로 둡니다.
+func runInit(args []string) error {
+	c := newCLIConfig(flag.NewFlagSet("init", flag.ContinueOnError))
+	c.registerNodeFlags()
+	if err := c.parse(args); err != nil {
+		return err
 	}
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	_, err := initHome(logger, c)
+	return err
 }
 
-func run(logger log.Logger) error {
-	logger.Info("Starting Politician application run function")
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+// runStart는 노드와 HTTP API 서버를 실행합니다. 홈 디렉터리가 초기화되지 않았으면 먼저 초기화합니다.
+func runStart(args []string) error {
+	c := newCLIConfig(flag.NewFlagSet("start", flag.ContinueOnError))
+	c.registerNodeFlags()
+	if err := c.parse(args); err != nil {
+		return err
+	}
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	if err := run(logger, c); err != nil {
+		logger.Error("Failed to run application", "error", err)
+		return err
 	}
-	politisianDir := filepath.Join(homeDir, "politisian")
-	cometbftDir := filepath.Join(politisianDir, ".cometbft")
-	logger.Info("Base directory paths configured", "politisianDir", politisianDir, "cometbftDir", cometbftDir)
+	return nil
+}
 
-	cfg := config.DefaultConfig()
-	cfg.SetRoot(cometbftDir)
-	cfg.RPC.ListenAddress = "tcp://0.0.0.0:26657"
-	cfg.Consensus.TimeoutCommit = 5 * time.Second
+// initHome은 홈 디렉터리의 설정, 키, 제네시스를 준비하고 노드 설정을 반환합니다.
+// config.toml이 이미 있으면 파일을 읽은 뒤 플래그나 설정 파일로 명시된 값만 덮어씁니다.
+func initHome(logger log.Logger, c *cliConfig) (*config.Config, error) {
+	cometbftDir := c.Home
+	logger.Info("Base directory paths configured", "cometbftDir", cometbftDir)
 
+	// EnsureRoot는 config.toml이 없으면 기본값으로 만들므로, 그 전에 파일이 있었는지 확인합니다.
+	configFilePath := filepath.Join(cometbftDir, "config", "config.toml")
+	_, statErr := os.Stat(configFilePath)
 	config.EnsureRoot(cometbftDir)
-	configFilePath := filepath.Join(cfg.RootDir, "config", "config.toml")
-	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
+	var cfg *config.Config
+	if os.IsNotExist(statErr) {
 		logger.Info("config.toml not found, creating a new one", "path", configFilePath)
+		cfg = config.DefaultConfig()
+		cfg.SetRoot(cometbftDir)
+		c.applyNodeConfig(cfg, false)
 		config.WriteConfigFile(configFilePath, cfg)
 	} else {
+		var err error
 		logger.Info("Using existing config.toml", "path", configFilePath)
+		if cfg, err = loadConfigFile(cometbftDir); err != nil {
+			return nil, err
+		}
+		c.applyNodeConfig(cfg, true)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid node config: %w", err)
 	}
 
 	logger.Info("Loading or generating private validator and node key", "pv_key_path", cfg.PrivValidatorKeyFile(), "node_key_path", cfg.NodeKeyFile())
 	pv := privval.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
-	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
-	if err != nil {
-		return fmt.Errorf("failed to load or gen node key: %w", err)
+	if _, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile()); err != nil {
+		return nil, fmt.Errorf("failed to load or gen node key: %w", err)
 	}
 
 	genesisFile := cfg.GenesisFile()
@@ -66,29 +151,49 @@ func run(logger log.Logger) error {
 		logger.Info("genesis.json not found, creating a new one", "path", genesisFile)
 		pubKey, err := pv.GetPubKey()
 		if err != nil {
-			return fmt.Errorf("failed to get pubkey from priv validator: %w", err)
+			return nil, fmt.Errorf("failed to get pubkey from priv validator: %w", err)
+		}
+		politicians, err := loadRoster(c.Roster)
+		if err != nil {
+			return nil, err
 		}
-		appState, err := json.Marshal(ptypes.GenesisState{})
+		registrar, err := parseRegistrarKey(c.KeyRegistrar)
 		if err != nil {
-			return fmt.Errorf("failed to marshal genesis app state: %w", err)
+			return nil, err
 		}
-		genDoc := &types.GenesisDoc{
-			ChainID:         "politisian-chain-1",
-			GenesisTime:     time.Now(),
-			ConsensusParams: types.DefaultConsensusParams(),
-			Validators: []types.GenesisValidator{{
-				Address: pubKey.Address(),
-				PubKey:  pubKey,
-				Power:   10,
-			}},
-			AppState: json.RawMessage(appState),
+		genDoc, err := newGenesisDoc(c.ChainID, []types.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   10,
+		}}, politicians, registrar)
+		if err != nil {
+			return nil, err
 		}
 		if err := genDoc.SaveAs(genesisFile); err != nil {
-			return fmt.Errorf("failed to save genesis file: %w", err)
+			return nil, fmt.Errorf("failed to save genesis file: %w", err)
 		}
 	} else {
 		logger.Info("Using existing genesis.json", "path", genesisFile)
 	}
+	return cfg, nil
+}
+
+func run(logger log.Logger, c *cliConfig) error {
+	logger.Info("Starting Politician application run function")
+	// 웹 서버가 사용자 대신 서명하므로 서명 비밀값이 없으면 노드를 시작하지 않습니다.
+	if err := server.LoadTxSigningSecret(); err != nil {
+		return err
+	}
+	cfg, err := initHome(logger, c)
+	if err != nil {
+		return err
+	}
+	cometbftDir := cfg.RootDir
+	pv := privval.LoadFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
+	nodeKey, err := p2p.LoadNodeKey(cfg.NodeKeyFile())
+	if err != nil {
+		return fmt.Errorf("failed to load node key: %w", err)
+	}
 
 	dbPath := filepath.Join(cometbftDir, "data")
 	logger.Info("Opening application database", "backend", "goleveldb", "path", dbPath)
@@ -97,8 +202,21 @@ func run(logger log.Logger) error {
 		return fmt.Errorf("failed to create db: %w", err)
 	}
 	abciApp := app.NewPoliticianApp(db, logger.With("module", "abci-app"))
+	snapshotOpts := app.SnapshotOptions{
+		Dir:        filepath.Join(dbPath, "snapshots"),
+		Interval:   c.SnapshotInterval,
+		KeepRecent: c.SnapshotKeepRecent,
+	}
+	if err := abciApp.EnableSnapshots(snapshotOpts); err != nil {
+		return err
+	}
 
 	genesisDocProvider := node.DefaultGenesisDocProviderFunc(cfg)
+	genDoc, err := genesisDocProvider()
+	if err != nil {
+		return fmt.Errorf("failed to load genesis: %w", err)
+	}
+	abciApp.SetChainID(genDoc.ChainID)
 	dbProvider := config.DefaultDBProvider
 
 	logger.Info("Creating CometBFT node...")
@@ -123,11 +241,84 @@ func run(logger log.Logger) error {
 
 	// 웹 서버 시작
 	logger.Info("Starting web server...")
-	go server.StartServer(cometNode)
+	go server.StartServer(cometNode, c.HTTPPort)
 
 	// 노드가 중지될 때까지 기다림
 	logger.Info("Node started. Waiting for interrupt signal to shut down.")
 	cometNode.Wait()
+	// 만들고 있던 스냅샷을 마무리한 뒤 종료합니다.
+	abciApp.WaitForSnapshots()
 
 	return nil
-}
\ No newline at end of file
+}
+
+// loadConfigFile은 home 아래의 config.toml을 읽습니다. 파일에 없는 값은 기본값을 사용합니다.
+func loadConfigFile(home string) (*config.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(home, "config", "config.toml"))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config.toml: %w", err)
+	}
+	cfg := config.DefaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config.toml: %w", err)
+	}
+	cfg.SetRoot(home)
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid config.toml: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadRoster는 path의 로스터 파일을 읽습니다. path가 비어 있으면 내장된 기본 로스터를 사용합니다.
+func loadRoster(path string) (map[string]*ptypes.Politician, error) {
+	if path != "" {
+		return ptypes.LoadPoliticianRoster(path)
+	}
+	politicians, err := ptypes.ParseRosterCSV(defaultRoster)
+	if err != nil {
+		return nil, fmt.Errorf("default roster: %w", err)
+	}
+	return ptypes.NewPoliticianRoster(politicians)
+}
+
+// parseRegistrarKey는 --key-registrar로 받은 16진수 공개키를 읽습니다.
+// 제네시스를 만드는 노드는 서명 비밀값을 갖지 않으므로, 웹 서버 쪽에서 'politisian registrar-key'로 출력한 값을 넘겨받습니다.
+func parseRegistrarKey(hexKey string) ([]byte, error) {
+	if hexKey == "" {
+		return nil, fmt.Errorf("--key-registrar is required to create a genesis (print it with 'politisian registrar-key' where TX_SIGNING_SECRET is set)")
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil || len(key) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("--key-registrar must be a %d-byte hex ed25519 public key", ed25519.PubKeySize)
+	}
+	return key, nil
+}
+
+// newGenesisDoc은 정치인 로스터, 기본 거버넌스 파라미터와 키 등록 공개키를 담은 애플리케이션 상태와 주어진 검증자로 제네시스 문서를 만듭니다.
+func newGenesisDoc(chainID string, validators []types.GenesisValidator, politicians map[string]*ptypes.Politician, registrar []byte) (*types.GenesisDoc, error) {
+	params := ptypes.DefaultGovParams()
+	state := ptypes.GenesisState{
+		Politicians: politicians,
+		GovParams:   &params,
+		AuthParams:  &ptypes.AuthParams{KeyRegistrar: registrar},
+	}
+	if err := state.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid genesis app state: %w", err)
+	}
+	appState, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis app state: %w", err)
+	}
+	genDoc := &types.GenesisDoc{
+		ChainID:         chainID,
+		GenesisTime:     time.Now(),
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      validators,
+		AppState:        json.RawMessage(appState),
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %w", err)
+	}
+	return genDoc, nil
+}
@@ -0,0 +1,197 @@
+package smt
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProofOpType은 CometBFT ProofOps에 기록되는 이 트리의 증명 타입입니다.
+const ProofOpType = "politisian:smt"
+
+// Proof는 키 하나의 포함 또는 비포함 증명입니다.
+// Siblings는 루트에서 경로를 따라 내려가며 만나는 형제 노드의 해시입니다.
+// 경로의 끝이 리프이면 LeafKeyHash/LeafValueHash가 설정되고, 빈 서브트리이면 둘 다 비어 있습니다.
+// 끝의 리프가 다른 키의 것이면 비포함 증명입니다.
+type Proof struct {
+	Siblings      [][]byte
+	LeafKeyHash   []byte
+	LeafValueHash []byte
+}
+
+// Prove는 key에 대한 증명을 만듭니다. 키가 없으면 비포함 증명이 됩니다.
+func (t *Tree) Prove(key []byte) *Proof {
+	kh := hash(key)
+	proof := &Proof{}
+	n := t.root
+	for depth := 0; n != nil && !n.leaf; depth++ {
+		if bit(kh, depth) == 0 {
+			proof.Siblings = append(proof.Siblings, hashOf(n.right))
+			n = n.left
+		} else {
+			proof.Siblings = append(proof.Siblings, hashOf(n.left))
+			n = n.right
+		}
+	}
+	if n != nil {
+		proof.LeafKeyHash, proof.LeafValueHash = n.keyHash, n.valueHash
+	}
+	return proof
+}
+
+// ComputeRoot는 key에 value가 있다고(value가 nil이면 key가 없다고) 가정했을 때의 루트를 계산합니다.
+// 증명이 그 가정과 맞지 않으면 에러를 반환합니다.
+func (p *Proof) ComputeRoot(key, value []byte) ([]byte, error) {
+	if len(p.Siblings) > 8*HashSize {
+		return nil, errors.New("proof is deeper than the key space")
+	}
+	kh := hash(key)
+	var h []byte
+	switch {
+	case value != nil:
+		if !bytes.Equal(p.LeafKeyHash, kh) || !bytes.Equal(p.LeafValueHash, hash(value)) {
+			return nil, errors.New("proof leaf does not match key and value")
+		}
+		h = leafHash(kh, p.LeafValueHash)
+	case len(p.LeafKeyHash) == 0:
+		// 경로가 빈 서브트리에서 끝나므로 키가 없습니다.
+		h = emptyHash
+	default:
+		// 경로 끝의 리프가 다른 키이면서 같은 경로 위에 있어야 키가 없음을 증명합니다.
+		if len(p.LeafKeyHash) != HashSize || len(p.LeafValueHash) != HashSize || bytes.Equal(p.LeafKeyHash, kh) {
+			return nil, errors.New("proof leaf does not prove absence of key")
+		}
+		for depth := range p.Siblings {
+			if bit(p.LeafKeyHash, depth) != bit(kh, depth) {
+				return nil, errors.New("proof leaf is not on the key path")
+			}
+		}
+		h = leafHash(p.LeafKeyHash, p.LeafValueHash)
+	}
+	for depth := len(p.Siblings) - 1; depth >= 0; depth-- {
+		sibling := p.Siblings[depth]
+		if len(sibling) != HashSize {
+			return nil, fmt.Errorf("sibling %d has invalid length %d", depth, len(sibling))
+		}
+		if bit(kh, depth) == 0 {
+			h = innerHash(h, sibling)
+		} else {
+			h = innerHash(sibling, h)
+		}
+	}
+	return h, nil
+}
+
+// Marshal은 증명을 protobuf 와이어 형식으로 인코딩합니다.
+func (p *Proof) Marshal() []byte {
+	var bz []byte
+	for _, sibling := range p.Siblings {
+		bz = protowire.AppendTag(bz, 1, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, sibling)
+	}
+	if len(p.LeafKeyHash) > 0 {
+		bz = protowire.AppendTag(bz, 2, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, p.LeafKeyHash)
+		bz = protowire.AppendTag(bz, 3, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, p.LeafValueHash)
+	}
+	return bz
+}
+
+// UnmarshalProof는 Marshal로 인코딩된 증명을 해석합니다.
+func UnmarshalProof(bz []byte) (*Proof, error) {
+	p := &Proof{}
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		if typ != protowire.BytesType {
+			return nil, fmt.Errorf("field %d: unexpected wire type %d", num, typ)
+		}
+		v, n := protowire.ConsumeBytes(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		v = append([]byte(nil), v...)
+		switch num {
+		case 1:
+			p.Siblings = append(p.Siblings, v)
+		case 2:
+			p.LeafKeyHash = v
+		case 3:
+			p.LeafValueHash = v
+		}
+	}
+	return p, nil
+}
+
+// ProofOp은 Proof를 CometBFT의 merkle.ProofOperator로 감쌉니다.
+// 포함 증명은 인자로 값 하나를, 비포함 증명은 인자 없이 실행하며 결과로 루트 해시를 돌려줍니다.
+type ProofOp struct {
+	Key   []byte
+	Proof *Proof
+}
+
+var _ merkle.ProofOperator = ProofOp{}
+
+// NewProofOp은 key에 대한 증명 연산을 만듭니다.
+func NewProofOp(key []byte, proof *Proof) ProofOp {
+	return ProofOp{Key: key, Proof: proof}
+}
+
+func (op ProofOp) Run(args [][]byte) ([][]byte, error) {
+	var value []byte
+	switch len(args) {
+	case 0:
+	case 1:
+		if args[0] == nil {
+			return nil, errors.New("value must not be nil; use an absence proof instead")
+		}
+		value = args[0]
+	default:
+		return nil, fmt.Errorf("expected at most 1 arg, got %d", len(args))
+	}
+	root, err := op.Proof.ComputeRoot(op.Key, value)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{root}, nil
+}
+
+func (op ProofOp) GetKey() []byte {
+	return op.Key
+}
+
+func (op ProofOp) ProofOp() cmtcrypto.ProofOp {
+	return cmtcrypto.ProofOp{Type: ProofOpType, Key: op.Key, Data: op.Proof.Marshal()}
+}
+
+// ProofOpDecoder는 merkle.ProofRuntime에 등록하는 디코더입니다.
+//
+//	prt := merkle.NewProofRuntime()
+//	prt.RegisterOpDecoder(smt.ProofOpType, smt.ProofOpDecoder)
+//	err := prt.VerifyValue(res.ProofOps, appHash, smt.KeyPath(res.Key), res.Value)
+func ProofOpDecoder(pop cmtcrypto.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpType {
+		return nil, fmt.Errorf("unexpected proof op type %q, want %q", pop.Type, ProofOpType)
+	}
+	proof, err := UnmarshalProof(pop.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode smt proof: %w", err)
+	}
+	return NewProofOp(pop.Key, proof), nil
+}
+
+// KeyPath는 상태 키를 merkle.ProofRuntime이 사용하는 키 경로 문자열로 바꿉니다.
+// 상태 키에는 '/'가 들어가므로 16진수 형식을 사용합니다.
+func KeyPath(key []byte) string {
+	return "/x:" + hex.EncodeToString(key)
+}
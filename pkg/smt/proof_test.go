@@ -0,0 +1,161 @@
+package smt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+)
+
+func proofTree(t *testing.T) (*Tree, map[string][]byte) {
+	t.Helper()
+	values := testValues(64)
+	tree := New()
+	for key, value := range values {
+		tree.Set([]byte(key), value)
+	}
+	return tree, values
+}
+
+// verify는 ProofRuntime으로 증명을 확인합니다. value가 nil이면 비포함 증명으로 확인합니다.
+func verify(proof *Proof, root, key, value []byte) error {
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(ProofOpType, ProofOpDecoder)
+	ops := &cmtcrypto.ProofOps{Ops: []cmtcrypto.ProofOp{NewProofOp(key, proof).ProofOp()}}
+	if value == nil {
+		return prt.VerifyAbsence(ops, root, KeyPath(key))
+	}
+	return prt.VerifyValue(ops, root, KeyPath(key), value)
+}
+
+// absentKey는 트리에 없는 키 중 경로가 다른 키의 리프에서(endsAtLeaf) 또는 빈 서브트리에서 끝나는 키를 찾습니다.
+func absentKey(t *testing.T, tree *Tree, endsAtLeaf bool) []byte {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		key := []byte("missing/" + string(rune('a'+i%26)) + string(rune('0'+i/26)))
+		if (len(tree.Prove(key).LeafKeyHash) > 0) == endsAtLeaf {
+			return key
+		}
+	}
+	t.Fatalf("no absent key found with endsAtLeaf=%v", endsAtLeaf)
+	return nil
+}
+
+func TestProveExistence(t *testing.T) {
+	tree, values := proofTree(t)
+	root := tree.Root()
+	for key, value := range values {
+		proof := tree.Prove([]byte(key))
+		if err := verify(proof, root, []byte(key), value); err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		// 인코딩을 거친 증명도 같은 결과를 냅니다.
+		decoded, err := UnmarshalProof(proof.Marshal())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := verify(decoded, root, []byte(key), value); err != nil {
+			t.Fatalf("%s after marshal: %v", key, err)
+		}
+		// 있는 키의 증명으로는 키가 없다고 증명할 수 없습니다.
+		if err := verify(proof, root, []byte(key), nil); err == nil {
+			t.Fatalf("%s: existence proof verified as absence", key)
+		}
+	}
+}
+
+func TestProveNonExistence(t *testing.T) {
+	tree, _ := proofTree(t)
+	root := tree.Root()
+	for name, key := range map[string][]byte{
+		"path ends in an empty subtree": absentKey(t, tree, false),
+		"path ends at another leaf":     absentKey(t, tree, true),
+	} {
+		proof := tree.Prove(key)
+		if err := verify(proof, root, key, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := verify(proof, root, key, []byte("value")); err == nil {
+			t.Errorf("%s: absence proof verified a value", name)
+		}
+	}
+
+	// 빈 트리에서는 어떤 키도 없다고 증명됩니다.
+	empty := New()
+	if err := verify(empty.Prove([]byte("any")), empty.Root(), []byte("any"), nil); err != nil {
+		t.Fatalf("empty tree: %v", err)
+	}
+}
+
+func TestTamperedProofs(t *testing.T) {
+	tree, values := proofTree(t)
+	root := tree.Root()
+	key, otherKey := "account/user-0", "account/user-1"
+	value := values[key]
+
+	clone := func(p *Proof) *Proof {
+		c := &Proof{LeafKeyHash: append([]byte(nil), p.LeafKeyHash...), LeafValueHash: append([]byte(nil), p.LeafValueHash...)}
+		for _, s := range p.Siblings {
+			c.Siblings = append(c.Siblings, append([]byte(nil), s...))
+		}
+		return c
+	}
+	proof := tree.Prove([]byte(key))
+	if len(proof.Siblings) == 0 {
+		t.Fatal("test tree is too small to have siblings")
+	}
+
+	tests := []struct {
+		name  string
+		proof func() *Proof
+		key   []byte
+		value []byte
+		root  []byte
+	}{
+		{name: "wrong value", proof: func() *Proof { return proof }, key: []byte(key), value: []byte("forged"), root: root},
+		{name: "other key's proof", proof: func() *Proof { return tree.Prove([]byte(otherKey)) }, key: []byte(key), value: value, root: root},
+		{name: "flipped sibling", proof: func() *Proof {
+			p := clone(proof)
+			p.Siblings[0][0] ^= 0x01
+			return p
+		}, key: []byte(key), value: value, root: root},
+		{name: "dropped sibling", proof: func() *Proof {
+			p := clone(proof)
+			p.Siblings = p.Siblings[:len(p.Siblings)-1]
+			return p
+		}, key: []byte(key), value: value, root: root},
+		{name: "short sibling", proof: func() *Proof {
+			p := clone(proof)
+			p.Siblings[0] = p.Siblings[0][:HashSize-1]
+			return p
+		}, key: []byte(key), value: value, root: root},
+		{name: "absence claimed with the key's own leaf", proof: func() *Proof { return proof }, key: []byte(key), root: root},
+		{name: "absence with an emptied path", proof: func() *Proof {
+			p := clone(proof)
+			p.LeafKeyHash, p.LeafValueHash = nil, nil
+			return p
+		}, key: []byte(key), root: root},
+		{name: "stale root", proof: func() *Proof { return proof }, key: []byte(key), value: value, root: New().Root()},
+		{name: "too deep", proof: func() *Proof {
+			p := clone(proof)
+			for len(p.Siblings) <= 8*HashSize {
+				p.Siblings = append(p.Siblings, make([]byte, HashSize))
+			}
+			return p
+		}, key: []byte(key), value: value, root: root},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verify(tt.proof(), tt.root, tt.key, tt.value); err == nil {
+				t.Fatal("tampered proof verified")
+			}
+		})
+	}
+
+	// 변조하지 않은 사본은 원래 루트를 계산하므로, 위의 실패는 복사가 아니라 변조 때문입니다.
+	got, err := clone(proof).ComputeRoot([]byte(key), value)
+	if err != nil || !bytes.Equal(got, root) {
+		t.Fatalf("untampered proof computes %X (%v), want %X", got, err, root)
+	}
+}
@@ -0,0 +1,198 @@
+// Package smt는 상태 키-값 쌍을 위한 압축 희소 머클 트리(compact sparse Merkle tree)를 제공합니다.
+//
+// 각 키는 sha256(key)의 256비트 경로에 놓이며, 리프는 다른 키와 갈라지는 가장 얕은 깊이에 저장됩니다.
+// 따라서 트리 모양은 키 집합만으로 결정되고, 갱신 비용은 전체 키 수가 아니라 바뀐 키 수 × 트리 깊이에 비례합니다.
+//
+// 해시 규칙:
+//   - 빈 서브트리: 32바이트 0
+//   - 리프: sha256(0x00 || sha256(key) || sha256(value))
+//   - 내부 노드: sha256(0x01 || left || right)
+package smt
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+const (
+	// HashSize는 트리에서 사용하는 해시 길이입니다.
+	HashSize = sha256.Size
+
+	leafPrefix  = 0x00
+	innerPrefix = 0x01
+)
+
+var emptyHash = make([]byte, HashSize)
+
+type node struct {
+	hash []byte
+
+	// 리프 노드
+	leaf      bool
+	keyHash   []byte
+	valueHash []byte
+
+	// 내부 노드
+	left, right *node
+}
+
+// Tree는 메모리에 유지되는 압축 희소 머클 트리입니다. 값 자체는 저장하지 않고 해시만 보관합니다.
+// 노드는 한 번 만들어지면 바뀌지 않으므로(갱신 시 경로만 복사) Snapshot으로 특정 시점의 트리를 싸게 보존할 수 있습니다.
+// 동시 접근으로부터 보호하지 않으므로 호출자가 동기화해야 합니다.
+type Tree struct {
+	root *node
+	size int
+}
+
+// New는 빈 트리를 만듭니다.
+func New() *Tree {
+	return &Tree{}
+}
+
+// Root는 현재 루트 해시를 반환합니다. 빈 트리의 루트는 32바이트 0입니다.
+func (t *Tree) Root() []byte {
+	return append([]byte(nil), hashOf(t.root)...)
+}
+
+// Size는 트리에 들어 있는 키의 수입니다.
+func (t *Tree) Size() int {
+	return t.size
+}
+
+// Snapshot은 현재 트리의 읽기 전용 사본을 반환합니다. 이후 원본을 갱신해도 사본은 바뀌지 않습니다.
+func (t *Tree) Snapshot() *Tree {
+	c := *t
+	return &c
+}
+
+// Set은 key의 값을 value로 설정합니다.
+func (t *Tree) Set(key, value []byte) {
+	kh, vh := hash(key), hash(value)
+	var inserted bool
+	t.root, inserted = insert(t.root, 0, kh, vh)
+	if inserted {
+		t.size++
+	}
+}
+
+// Delete는 key를 트리에서 제거합니다. 없는 키는 무시합니다.
+func (t *Tree) Delete(key []byte) {
+	var removed bool
+	t.root, removed = remove(t.root, 0, hash(key))
+	if removed {
+		t.size--
+	}
+}
+
+func insert(n *node, depth int, kh, vh []byte) (*node, bool) {
+	if n == nil {
+		return newLeaf(kh, vh), true
+	}
+	if n.leaf {
+		if bytes.Equal(n.keyHash, kh) {
+			return newLeaf(kh, vh), false
+		}
+		return split(n, newLeaf(kh, vh), depth), true
+	}
+	c := &node{left: n.left, right: n.right}
+	var inserted bool
+	if bit(kh, depth) == 0 {
+		c.left, inserted = insert(n.left, depth+1, kh, vh)
+	} else {
+		c.right, inserted = insert(n.right, depth+1, kh, vh)
+	}
+	c.rehash()
+	return c, inserted
+}
+
+// split은 같은 위치에 놓이게 된 두 리프를 경로가 갈라지는 깊이까지 내려 배치합니다.
+func split(a, b *node, depth int) *node {
+	abit, bbit := bit(a.keyHash, depth), bit(b.keyHash, depth)
+	n := &node{}
+	switch {
+	case abit == bbit && abit == 0:
+		n.left = split(a, b, depth+1)
+	case abit == bbit:
+		n.right = split(a, b, depth+1)
+	case abit == 0:
+		n.left, n.right = a, b
+	default:
+		n.left, n.right = b, a
+	}
+	n.rehash()
+	return n
+}
+
+func remove(n *node, depth int, kh []byte) (*node, bool) {
+	if n == nil {
+		return nil, false
+	}
+	if n.leaf {
+		if bytes.Equal(n.keyHash, kh) {
+			return nil, true
+		}
+		return n, false
+	}
+	c := &node{left: n.left, right: n.right}
+	var removed bool
+	if bit(kh, depth) == 0 {
+		c.left, removed = remove(n.left, depth+1, kh)
+	} else {
+		c.right, removed = remove(n.right, depth+1, kh)
+	}
+	if !removed {
+		return n, false
+	}
+	// 리프 하나만 남은 서브트리는 리프를 위로 끌어올려 모양을 키 집합에 대해 유일하게 유지합니다.
+	switch {
+	case c.left == nil && c.right == nil:
+		return nil, true
+	case c.left == nil && c.right.leaf:
+		return c.right, true
+	case c.right == nil && c.left.leaf:
+		return c.left, true
+	}
+	c.rehash()
+	return c, true
+}
+
+func newLeaf(kh, vh []byte) *node {
+	return &node{leaf: true, keyHash: kh, valueHash: vh, hash: leafHash(kh, vh)}
+}
+
+func (n *node) rehash() {
+	n.hash = innerHash(hashOf(n.left), hashOf(n.right))
+}
+
+func hashOf(n *node) []byte {
+	if n == nil {
+		return emptyHash
+	}
+	return n.hash
+}
+
+func hash(bz []byte) []byte {
+	h := sha256.Sum256(bz)
+	return h[:]
+}
+
+func leafHash(kh, vh []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(kh)
+	h.Write(vh)
+	return h.Sum(nil)
+}
+
+func innerHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{innerPrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// bit는 경로의 depth번째 비트(최상위 비트부터)를 반환합니다.
+func bit(path []byte, depth int) int {
+	return int(path[depth/8]>>(7-uint(depth%8))) & 1
+}
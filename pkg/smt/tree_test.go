@@ -0,0 +1,178 @@
+package smt
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// referenceRoot는 키 집합에서 바로 루트를 계산합니다. 리프는 다른 키와 갈라지는 가장 얕은 깊이에 놓이므로,
+// 한 깊이의 키를 다음 비트로 나누어 재귀하면 트리가 만드는 것과 같은 루트가 나와야 합니다.
+func referenceRoot(values map[string][]byte) []byte {
+	type leaf struct{ kh, vh []byte }
+	leaves := make([]leaf, 0, len(values))
+	for key, value := range values {
+		leaves = append(leaves, leaf{hash([]byte(key)), hash(value)})
+	}
+	var root func(leaves []leaf, depth int) []byte
+	root = func(leaves []leaf, depth int) []byte {
+		switch len(leaves) {
+		case 0:
+			return emptyHash
+		case 1:
+			return leafHash(leaves[0].kh, leaves[0].vh)
+		}
+		var left, right []leaf
+		for _, l := range leaves {
+			if bit(l.kh, depth) == 0 {
+				left = append(left, l)
+			} else {
+				right = append(right, l)
+			}
+		}
+		return innerHash(root(left, depth+1), root(right, depth+1))
+	}
+	return root(leaves, 0)
+}
+
+func testValues(n int) map[string][]byte {
+	values := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		values[fmt.Sprintf("account/user-%d", i)] = []byte(fmt.Sprintf("value-%d", i))
+	}
+	return values
+}
+
+func treeOf(keys []string, values map[string][]byte) *Tree {
+	tree := New()
+	for _, key := range keys {
+		tree.Set([]byte(key), values[key])
+	}
+	return tree
+}
+
+func TestEmptyTree(t *testing.T) {
+	tree := New()
+	if !bytes.Equal(tree.Root(), make([]byte, HashSize)) || tree.Size() != 0 {
+		t.Fatalf("empty tree root %X size %d, want zero hash and 0", tree.Root(), tree.Size())
+	}
+	tree.Delete([]byte("missing"))
+	if !bytes.Equal(tree.Root(), make([]byte, HashSize)) || tree.Size() != 0 {
+		t.Fatal("deleting from an empty tree changed it")
+	}
+}
+
+// 루트는 키 집합과 값만으로 정해지며, 넣는 순서와 관계없습니다.
+func TestRootIsOrderIndependent(t *testing.T) {
+	values := testValues(200)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	want := referenceRoot(values)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5; i++ {
+		rng.Shuffle(len(keys), func(a, b int) { keys[a], keys[b] = keys[b], keys[a] })
+		tree := treeOf(keys, values)
+		if !bytes.Equal(tree.Root(), want) {
+			t.Fatalf("shuffle %d: root %X, want %X", i, tree.Root(), want)
+		}
+		if tree.Size() != len(values) {
+			t.Fatalf("shuffle %d: size %d, want %d", i, tree.Size(), len(values))
+		}
+	}
+}
+
+// 값을 바꾸면 루트가 바뀌고, 원래 값으로 되돌리면 원래 루트가 됩니다. 키 수는 바뀌지 않습니다.
+func TestUpdate(t *testing.T) {
+	values := testValues(50)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	tree := treeOf(keys, values)
+	before := tree.Root()
+
+	key := keys[0]
+	tree.Set([]byte(key), []byte("changed"))
+	if bytes.Equal(tree.Root(), before) {
+		t.Fatal("updating a value did not change the root")
+	}
+	changed := map[string][]byte{}
+	for k, v := range values {
+		changed[k] = v
+	}
+	changed[key] = []byte("changed")
+	if !bytes.Equal(tree.Root(), referenceRoot(changed)) {
+		t.Fatal("root after update does not match the reference")
+	}
+	if tree.Size() != len(values) {
+		t.Fatalf("size after update = %d, want %d", tree.Size(), len(values))
+	}
+
+	tree.Set([]byte(key), values[key])
+	if !bytes.Equal(tree.Root(), before) {
+		t.Fatal("restoring the value did not restore the root")
+	}
+}
+
+// 키를 지우면 그 키를 넣은 적 없는 트리와 같은 루트가 되고, 모두 지우면 빈 트리가 됩니다.
+func TestDelete(t *testing.T) {
+	values := testValues(100)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	rng := rand.New(rand.NewSource(2))
+	rng.Shuffle(len(keys), func(a, b int) { keys[a], keys[b] = keys[b], keys[a] })
+	tree := treeOf(keys, values)
+
+	tree.Delete([]byte("missing"))
+	if !bytes.Equal(tree.Root(), referenceRoot(values)) || tree.Size() != len(values) {
+		t.Fatal("deleting a missing key changed the tree")
+	}
+
+	remaining := map[string][]byte{}
+	for k, v := range values {
+		remaining[k] = v
+	}
+	for i, key := range keys {
+		tree.Delete([]byte(key))
+		delete(remaining, key)
+		if !bytes.Equal(tree.Root(), referenceRoot(remaining)) {
+			t.Fatalf("after deleting %d keys: root does not match a tree built without them", i+1)
+		}
+		if tree.Size() != len(remaining) {
+			t.Fatalf("after deleting %d keys: size %d, want %d", i+1, tree.Size(), len(remaining))
+		}
+	}
+	if !bytes.Equal(tree.Root(), make([]byte, HashSize)) {
+		t.Fatalf("root after deleting every key = %X, want the empty root", tree.Root())
+	}
+
+	// 빈 트리가 된 뒤에도 다시 넣으면 처음부터 만든 트리와 같습니다.
+	tree.Set([]byte(keys[0]), values[keys[0]])
+	if !bytes.Equal(tree.Root(), referenceRoot(map[string][]byte{keys[0]: values[keys[0]]})) || tree.Size() != 1 {
+		t.Fatal("re-inserting into an emptied tree does not match a fresh tree")
+	}
+}
+
+// Snapshot은 이후 원본의 변경에 영향을 받지 않습니다.
+func TestSnapshotIsImmutable(t *testing.T) {
+	values := testValues(20)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	tree := treeOf(keys, values)
+	snapshot := tree.Snapshot()
+	root := snapshot.Root()
+
+	tree.Set([]byte(keys[0]), []byte("changed"))
+	tree.Delete([]byte(keys[1]))
+	tree.Set([]byte("new"), []byte("value"))
+	if !bytes.Equal(snapshot.Root(), root) || snapshot.Size() != len(values) {
+		t.Fatal("snapshot changed after updating the original tree")
+	}
+}
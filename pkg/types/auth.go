@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+// AuthParams는 계정 인증에 관한 파라미터입니다.
+// 상태에 저장되어 앱 해시에 포함되며, 제네시스의 auth_params로 정합니다.
+type AuthParams struct {
+	// KeyRegistrar는 계정을 만들고(create_profile), 확인된 입금을 기록하고(deposit_stablecoin),
+	// 공개키가 등록되기 전에 만들어진 계정에 키를 등록하는(bind_account_key) ed25519 공개키입니다.
+	// 비어 있으면 이 액션들은 모두 거부됩니다.
+	KeyRegistrar []byte `json:"key_registrar,omitempty"`
+}
+
+// Validate는 등록 키가 비어 있거나 올바른 길이의 ed25519 공개키인지 확인합니다.
+func (p AuthParams) Validate() error {
+	if len(p.KeyRegistrar) != 0 && len(p.KeyRegistrar) != ed25519.PubKeySize {
+		return fmt.Errorf("key_registrar must be empty or a %d-byte ed25519 public key", ed25519.PubKeySize)
+	}
+	return nil
+}
+
+// registrarActions는 계정 소유자가 아니라 AuthParams.KeyRegistrar 키가 서명해야 하는 액션입니다.
+var registrarActions = map[string]bool{
+	"create_profile":     true,
+	"deposit_stablecoin": true,
+	"bind_account_key":   true,
+}
+
+// IsRegistrarAction은 action이 등록 키의 서명을 요구하는지 반환합니다.
+func IsRegistrarAction(action string) bool {
+	return registrarActions[action]
+}
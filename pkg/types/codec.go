@@ -0,0 +1,247 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// 이 파일은 트랜잭션과 상태를 protobuf 와이어 형식으로 인코딩하는 공통 도구입니다.
+// 같은 값은 항상 같은 바이트가 되도록 다음 규칙을 따릅니다.
+//   - 필드는 번호 순서대로 한 번씩만 기록하고, 기본값(0, "", false, 빈 목록)은 생략합니다.
+//   - 맵은 {1: key, 2: value} 항목의 반복 필드로 기록하며 키 순서로 정렬합니다.
+// 디코더는 알 수 없는 필드를 건너뛰므로 필드를 추가해도 이전 데이터를 읽을 수 있습니다.
+// 다만 체인에 제출되는 트랜잭션(SignedTx, TxData)은 다시 인코딩했을 때 같은 바이트가 되는 경우만 받습니다.
+// 같은 내용의 트랜잭션이 다른 바이트(다른 해시)로 제출되지 않도록, 중복되거나 순서가 바뀐 필드,
+// 기록된 기본값, 알 수 없는 필드, 최소 길이가 아닌 varint는 ErrNonCanonical로 거부합니다.
+
+// ErrNonCanonical은 트랜잭션 바이트가 인코더가 만드는 정규 형식이 아닐 때 반환됩니다.
+var ErrNonCanonical = errors.New("non-canonical encoding")
+
+// protoEncoder는 메시지 하나의 필드를 순서대로 기록합니다.
+type protoEncoder struct {
+	buf []byte
+}
+
+func (e *protoEncoder) string(num protowire.Number, v string) {
+	if v == "" {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendString(e.buf, v)
+}
+
+func (e *protoEncoder) bytes(num protowire.Number, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendBytes(e.buf, v)
+}
+
+func (e *protoEncoder) int64(num protowire.Number, v int64) {
+	e.uint64(num, uint64(v))
+}
+
+func (e *protoEncoder) uint64(num protowire.Number, v uint64) {
+	if v == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, v)
+}
+
+func (e *protoEncoder) bool(num protowire.Number, v bool) {
+	if !v {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, 1)
+}
+
+func (e *protoEncoder) strings(num protowire.Number, vs []string) {
+	for _, v := range vs {
+		e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+		e.buf = protowire.AppendString(e.buf, v)
+	}
+}
+
+// message는 하위 메시지를 기록합니다. 빈 메시지도 기록하므로 존재 여부가 보존됩니다.
+func (e *protoEncoder) message(num protowire.Number, encode func(*protoEncoder)) {
+	var sub protoEncoder
+	encode(&sub)
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendBytes(e.buf, sub.buf)
+}
+
+func (e *protoEncoder) int64Map(num protowire.Number, m map[string]int64) {
+	for _, key := range sortedKeys(m) {
+		e.message(num, func(entry *protoEncoder) {
+			entry.string(1, key)
+			entry.int64(2, m[key])
+		})
+	}
+}
+
+func (e *protoEncoder) boolMap(num protowire.Number, m map[string]bool) {
+	for _, key := range sortedKeys(m) {
+		e.message(num, func(entry *protoEncoder) {
+			entry.string(1, key)
+			entry.bool(2, m[key])
+		})
+	}
+}
+
+// messageMap은 값이 메시지인 맵을 키 순서로 기록합니다.
+func messageMap[V any](e *protoEncoder, num protowire.Number, m map[string]V, encode func(*protoEncoder, V)) {
+	for _, key := range sortedKeys(m) {
+		e.message(num, func(entry *protoEncoder) {
+			entry.string(1, key)
+			entry.message(2, func(value *protoEncoder) { encode(value, m[key]) })
+		})
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// protoField는 디코딩 중인 필드 하나입니다.
+type protoField struct {
+	num    protowire.Number
+	typ    protowire.Type
+	varint uint64
+	bytes  []byte
+}
+
+// walkFields는 메시지의 필드를 순서대로 fn에 전달합니다. 알 수 없는 와이어 타입의 필드는 건너뜁니다.
+func walkFields(bz []byte, fn func(f protoField) error) error {
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		f := protoField{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(bz)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(bz)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, bz)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f protoField) expect(typ protowire.Type) error {
+	if f.typ != typ {
+		return fmt.Errorf("field %d: unexpected wire type %d", f.num, f.typ)
+	}
+	return nil
+}
+
+func (f protoField) string() (string, error) {
+	return string(f.bytes), f.expect(protowire.BytesType)
+}
+
+func (f protoField) politicianID() (PoliticianID, error) {
+	return PoliticianID(f.bytes), f.expect(protowire.BytesType)
+}
+
+func (f protoField) byteSlice() ([]byte, error) {
+	return append([]byte(nil), f.bytes...), f.expect(protowire.BytesType)
+}
+
+func (f protoField) int64() (int64, error) {
+	return int64(f.varint), f.expect(protowire.VarintType)
+}
+
+func (f protoField) uint64() (uint64, error) {
+	return f.varint, f.expect(protowire.VarintType)
+}
+
+func (f protoField) bool() (bool, error) {
+	return f.varint != 0, f.expect(protowire.VarintType)
+}
+
+// message는 하위 메시지 바이트를 decode에 전달합니다.
+func (f protoField) message(decode func([]byte) error) error {
+	if err := f.expect(protowire.BytesType); err != nil {
+		return err
+	}
+	return decode(f.bytes)
+}
+
+// mapEntry는 {1: key, 2: value} 형태의 맵 항목을 읽습니다.
+// 값이 기본값이라 생략된 경우 hasValue는 false입니다.
+func (f protoField) mapEntry() (key string, value protoField, hasValue bool, err error) {
+	err = f.message(func(bz []byte) error {
+		return walkFields(bz, func(entry protoField) (err error) {
+			switch entry.num {
+			case 1:
+				key, err = entry.string()
+			case 2:
+				value, hasValue = entry, true
+			}
+			return err
+		})
+	})
+	return key, value, hasValue, err
+}
+
+func decodeInt64Entry(f protoField, m map[string]int64) error {
+	key, value, hasValue, err := f.mapEntry()
+	if err != nil {
+		return err
+	}
+	if !hasValue {
+		m[key] = 0
+		return nil
+	}
+	m[key], err = value.int64()
+	return err
+}
+
+func decodeBoolEntry(f protoField, m map[string]bool) error {
+	key, value, hasValue, err := f.mapEntry()
+	if err != nil {
+		return err
+	}
+	if !hasValue {
+		m[key] = false
+		return nil
+	}
+	m[key], err = value.bool()
+	return err
+}
+
+func decodeMessageEntry[V any](f protoField, m map[string]*V, decode func([]byte, *V) error) error {
+	key, value, hasValue, err := f.mapEntry()
+	if err != nil {
+		return err
+	}
+	v := new(V)
+	if hasValue {
+		if err := value.message(func(bz []byte) error { return decode(bz, v) }); err != nil {
+			return err
+		}
+	}
+	m[key] = v
+	return nil
+}
@@ -0,0 +1,378 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// codecCase는 types.proto의 메시지 하나와 그 메시지를 인코딩·디코딩하는 함수입니다.
+type codecCase struct {
+	message   string
+	newValue  func() any
+	marshal   func(any) []byte
+	unmarshal func([]byte) (any, error)
+}
+
+func caseOf[T any](message string, marshal func(*T) []byte, unmarshal func([]byte) (*T, error)) codecCase {
+	return codecCase{
+		message:   message,
+		newValue:  func() any { return new(T) },
+		marshal:   func(v any) []byte { return marshal(v.(*T)) },
+		unmarshal: func(bz []byte) (any, error) { return unmarshal(bz) },
+	}
+}
+
+// txMsgField는 TxMsg 안에 인코딩된 메시지 하나의 바이트를 꺼냅니다. TxMsg의 메시지는 따로 인코딩하는 함수가 없습니다.
+func txMsgField(m *TxMsg, num protowire.Number) []byte {
+	var field []byte
+	_ = walkFields(marshalWith(encodeTxMsg, m), func(f protoField) error {
+		if f.num == num {
+			field = f.bytes
+		}
+		return nil
+	})
+	return field
+}
+
+func codecCases() []codecCase {
+	return []codecCase{
+		caseOf("SignedTx", MarshalSignedTx, UnmarshalSignedTx),
+		caseOf("TxData", MarshalTxData, UnmarshalTxData),
+		caseOf("TxMsg",
+			func(m *TxMsg) []byte { return marshalWith(encodeTxMsg, m) },
+			func(bz []byte) (*TxMsg, error) { return unmarshalWith(bz, decodeTxMsg) }),
+		caseOf("PlaceOrderMsg",
+			func(m *PlaceOrderMsg) []byte { return txMsgField(&TxMsg{PlaceOrder: m}, 1) },
+			func(bz []byte) (*PlaceOrderMsg, error) { return unmarshalWith(bz, decodePlaceOrderMsg) }),
+		caseOf("CancelOrderMsg",
+			func(m *CancelOrderMsg) []byte { return txMsgField(&TxMsg{CancelOrder: m}, 2) },
+			func(bz []byte) (*CancelOrderMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *CancelOrderMsg) error { return decodeOrderID(bz, &m.OrderID) })
+			}),
+		caseOf("ReleaseEscrowMsg",
+			func(m *ReleaseEscrowMsg) []byte { return txMsgField(&TxMsg{ReleaseEscrow: m}, 4) },
+			func(bz []byte) (*ReleaseEscrowMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *ReleaseEscrowMsg) error { return decodeOrderID(bz, &m.OrderID) })
+			}),
+		caseOf("DepositMsg",
+			func(m *DepositMsg) []byte { return txMsgField(&TxMsg{Deposit: m}, 5) },
+			func(bz []byte) (*DepositMsg, error) { return unmarshalWith(bz, decodeDepositMsg) }),
+		caseOf("WithdrawMsg",
+			func(m *WithdrawMsg) []byte { return txMsgField(&TxMsg{Withdraw: m}, 6) },
+			func(bz []byte) (*WithdrawMsg, error) { return unmarshalWith(bz, decodeWithdrawMsg) }),
+		caseOf("BindKeyMsg",
+			func(m *BindKeyMsg) []byte { return txMsgField(&TxMsg{BindKey: m}, 7) },
+			func(bz []byte) (*BindKeyMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *BindKeyMsg) error { return decodeAuthKey(bz, &m.PubKey) })
+			}),
+		caseOf("CreateAccountMsg",
+			func(m *CreateAccountMsg) []byte { return txMsgField(&TxMsg{CreateAccount: m}, 8) },
+			func(bz []byte) (*CreateAccountMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *CreateAccountMsg) error { return decodeAuthKey(bz, &m.PubKey) })
+			}),
+		caseOf("ProposeAmendmentMsg",
+			func(m *ProposeAmendmentMsg) []byte { return txMsgField(&TxMsg{ProposeAmendment: m}, 9) },
+			func(bz []byte) (*ProposeAmendmentMsg, error) { return unmarshalWith(bz, decodeProposeAmendmentMsg) }),
+		caseOf("ProposeDelistingMsg",
+			func(m *ProposeDelistingMsg) []byte { return txMsgField(&TxMsg{ProposeDelisting: m}, 10) },
+			func(bz []byte) (*ProposeDelistingMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *ProposeDelistingMsg) error { return decodeProposalTarget(bz, &m.PoliticianID) })
+			}),
+		caseOf("WithdrawProposalMsg",
+			func(m *WithdrawProposalMsg) []byte { return txMsgField(&TxMsg{WithdrawProposal: m}, 11) },
+			func(bz []byte) (*WithdrawProposalMsg, error) {
+				return unmarshalWith(bz, func(bz []byte, m *WithdrawProposalMsg) error { return decodeOrderID(bz, &m.ProposalID) })
+			}),
+		caseOf("AppState", MarshalAppState, UnmarshalAppState),
+		caseOf("Account", MarshalAccount, UnmarshalAccount),
+		caseOf("Politician", MarshalPolitician, UnmarshalPolitician),
+		caseOf("Proposal", MarshalProposal, UnmarshalProposal),
+		caseOf("TradeOrder", MarshalTradeOrder, UnmarshalTradeOrder),
+		caseOf("EscrowAccount", MarshalEscrowAccount, UnmarshalEscrowAccount),
+		caseOf("Trade", MarshalTrade, UnmarshalTrade),
+		caseOf("GovParams", MarshalGovParams, UnmarshalGovParams),
+		caseOf("AuthParams", MarshalAuthParams, UnmarshalAuthParams),
+	}
+}
+
+// filler는 값의 모든 필드를 서로 다른 기본값이 아닌 값으로 채웁니다.
+// 빠진 필드가 있으면 왕복한 값이 달라지므로, 코덱이 모든 필드를 기록하는지 확인할 수 있습니다.
+type filler struct{ n int64 }
+
+func (f *filler) next() int64 {
+	f.n++
+	return f.n
+}
+
+func (f *filler) fill(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("값-" + strconv.FormatInt(f.next(), 10))
+	case reflect.Int, reflect.Int64:
+		// 음수도 varint 10바이트로 왕복해야 합니다.
+		v.SetInt(-f.next() * 1_000_003)
+	case reflect.Uint64:
+		v.SetUint(uint64(f.next()) << 40)
+	case reflect.Uint8:
+		v.SetUint(uint64(f.next()))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		f.fill(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f.fill(v.Field(i))
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < 2; i++ {
+			f.fill(v.Index(i))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		for i := 0; i < 2; i++ {
+			key, value := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+			f.fill(key)
+			f.fill(value)
+			v.SetMapIndex(key, value)
+		}
+	default:
+		panic(fmt.Sprintf("filler: unsupported kind %s", v.Kind()))
+	}
+}
+
+// 모든 필드를 채운 메시지는 인코딩한 뒤 디코딩하면 같은 값이 되고, 다시 인코딩하면 같은 바이트가 됩니다.
+func TestCodecRoundTrip(t *testing.T) {
+	for _, tc := range codecCases() {
+		t.Run(tc.message, func(t *testing.T) {
+			want := tc.newValue()
+			(&filler{}).fill(reflect.ValueOf(want).Elem())
+			bz := tc.marshal(want)
+			got, err := tc.unmarshal(bz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+			}
+			if again := tc.marshal(got); !bytes.Equal(again, bz) {
+				t.Fatalf("re-encoding is not deterministic:\n got %X\nwant %X", again, bz)
+			}
+		})
+	}
+}
+
+// protoSchemaField는 types.proto에 선언된 필드 하나입니다.
+type protoSchemaField struct {
+	name string
+	typ  string
+}
+
+var (
+	protoMessageLine = regexp.MustCompile(`^message (\w+) \{`)
+	protoFieldLine   = regexp.MustCompile(`^(repeated )?(map<\w+, \w+>|\w+) (\w+) = (\d+);`)
+)
+
+// parseProtoSchema는 types.proto를 읽어 메시지별로 필드 번호와 필드를 모읍니다.
+// 이 파일에서 쓰는 문법(최상위 메시지, oneof, reserved, 한 줄에 한 필드)만 해석합니다.
+func parseProtoSchema(t *testing.T) map[string]map[protowire.Number]protoSchemaField {
+	t.Helper()
+	file, err := os.Open("types.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	schema := make(map[string]map[protowire.Number]protoSchemaField)
+	var message string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := protoMessageLine.FindStringSubmatch(line); m != nil {
+			message = m[1]
+			schema[message] = make(map[protowire.Number]protoSchemaField)
+			continue
+		}
+		m := protoFieldLine.FindStringSubmatch(line)
+		if m == nil || message == "" {
+			continue
+		}
+		num, _ := strconv.Atoi(m[4])
+		typ := m[2]
+		if m[1] != "" {
+			typ = "repeated " + typ
+		}
+		schema[message][protowire.Number(num)] = protoSchemaField{name: m[3], typ: typ}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// wireType은 proto 타입이 와이어에 기록되는 형식입니다. 반복 스칼라 필드는 이 스키마에 없습니다.
+func (f protoSchemaField) wireType() protowire.Type {
+	switch f.typ {
+	case "int64", "uint64", "bool":
+		return protowire.VarintType
+	}
+	return protowire.BytesType
+}
+
+// topLevelFields는 인코딩된 메시지의 최상위 필드를 번호별로 모읍니다.
+func topLevelFields(t *testing.T, bz []byte) map[protowire.Number][]protoField {
+	t.Helper()
+	fields := make(map[protowire.Number][]protoField)
+	if err := walkFields(bz, func(f protoField) error {
+		fields[f.num] = append(fields[f.num], f)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+// types.proto는 코덱과 같아야 합니다. Go 필드 하나만 채워 인코딩했을 때 바뀌는 필드 번호가
+// 스키마에서 그 필드의 JSON 이름과 같은 이름으로, 같은 와이어 형식으로 선언되어 있어야 하고,
+// 스키마의 모든 필드는 Go 필드 하나에 대응해야 합니다.
+func TestProtoSchemaMatchesCodec(t *testing.T) {
+	schema := parseProtoSchema(t)
+	cases := codecCases()
+	if len(schema) != len(cases) {
+		t.Errorf("types.proto declares %d messages, codec has %d", len(schema), len(cases))
+	}
+	for _, tc := range cases {
+		t.Run(tc.message, func(t *testing.T) {
+			declared, ok := schema[tc.message]
+			if !ok {
+				t.Fatalf("message %s is not declared in types.proto", tc.message)
+			}
+			zero := topLevelFields(t, tc.marshal(tc.newValue()))
+			typ := reflect.TypeOf(tc.newValue()).Elem()
+			matched := make(map[protowire.Number]bool)
+			for i := 0; i < typ.NumField(); i++ {
+				goField := typ.Field(i)
+				jsonName, _, _ := strings.Cut(goField.Tag.Get("json"), ",")
+
+				value := tc.newValue()
+				(&filler{}).fill(reflect.ValueOf(value).Elem().Field(i))
+				var changed []protowire.Number
+				for num, fields := range topLevelFields(t, tc.marshal(value)) {
+					if !reflect.DeepEqual(fields, zero[num]) {
+						changed = append(changed, num)
+					}
+				}
+				if len(changed) != 1 {
+					t.Errorf("%s changes fields %v when set, want exactly one", goField.Name, changed)
+					continue
+				}
+				num := changed[0]
+				field, ok := declared[num]
+				if !ok {
+					t.Errorf("%s is encoded as field %d, which types.proto does not declare", goField.Name, num)
+					continue
+				}
+				if field.name != jsonName {
+					t.Errorf("field %d is %q in types.proto, but the codec writes %s (%q) there", num, field.name, goField.Name, jsonName)
+				}
+				encoded := topLevelFields(t, tc.marshal(value))[num][0]
+				if encoded.typ != field.wireType() {
+					t.Errorf("field %d (%s %s) has wire type %d, want %d", num, field.typ, field.name, encoded.typ, field.wireType())
+				}
+				matched[num] = true
+			}
+			for num, field := range declared {
+				if !matched[num] {
+					t.Errorf("types.proto declares field %d (%s) that the codec does not write", num, field.name)
+				}
+			}
+		})
+	}
+}
+
+// 트랜잭션은 인코더가 만드는 정규 바이트만 받습니다. 같은 내용을 다른 바이트로 바꾼 입력은 모두 거부합니다.
+func TestRejectsNonCanonicalTx(t *testing.T) {
+	tx := &TxData{Action: "cancel_order", UserID: "alice", Msg: &TxMsg{CancelOrder: &CancelOrderMsg{OrderID: "order-1"}}}
+	canonical := MarshalTxData(tx)
+	str := func(num protowire.Number, v string) []byte {
+		return protowire.AppendString(protowire.AppendTag(nil, num, protowire.BytesType), v)
+	}
+	varint := func(num protowire.Number, v uint64) []byte {
+		return protowire.AppendVarint(protowire.AppendTag(nil, num, protowire.VarintType), v)
+	}
+	msg := func(fields ...[]byte) []byte {
+		return protowire.AppendBytes(protowire.AppendTag(nil, 14, protowire.BytesType), bytes.Join(fields, nil))
+	}
+	join := func(fields ...[]byte) []byte { return bytes.Join(fields, nil) }
+	cancelOf := func(fields ...[]byte) []byte {
+		return protowire.AppendBytes(protowire.AppendTag(nil, 2, protowire.BytesType), join(fields...))
+	}
+	cancel := cancelOf(str(1, "order-1"))
+
+	if got := join(str(2, "cancel_order"), str(3, "alice"), msg(cancel)); !bytes.Equal(got, canonical) {
+		t.Fatalf("test encoding %X does not match MarshalTxData %X", got, canonical)
+	}
+	if _, err := UnmarshalTxData(canonical); err != nil {
+		t.Fatalf("canonical tx data rejected: %v", err)
+	}
+
+	txDataTests := []struct {
+		name string
+		bz   []byte
+	}{
+		{"duplicate field", join(str(2, "cancel_order"), str(3, "alice"), str(3, "alice"), msg(cancel))},
+		{"duplicate field with a different value", join(str(2, "cancel_order"), str(3, "mallory"), str(3, "alice"), msg(cancel))},
+		{"out-of-order fields", join(str(3, "alice"), str(2, "cancel_order"), msg(cancel))},
+		{"explicit default value", join(str(2, "cancel_order"), str(3, "alice"), str(4, ""), msg(cancel))},
+		{"explicit false", join(str(2, "cancel_order"), str(3, "alice"), varint(12, 0), msg(cancel))},
+		{"bool other than 1", join(str(2, "cancel_order"), str(3, "alice"), varint(12, 2), msg(cancel))},
+		{"unknown field", join(str(2, "cancel_order"), str(3, "alice"), msg(cancel), str(99, "x"))},
+		{"duplicate nested message", join(str(2, "cancel_order"), str(3, "alice"), msg(cancel), msg(cancel))},
+		{"non-canonical nested message", join(str(2, "cancel_order"), str(3, "alice"), msg(cancelOf(str(1, "order-1"), str(1, "order-1"))))},
+	}
+	for _, tt := range txDataTests {
+		t.Run("TxData/"+tt.name, func(t *testing.T) {
+			if got, err := UnmarshalTxData(tt.bz); !errors.Is(err, ErrNonCanonical) {
+				t.Fatalf("UnmarshalTxData = %+v, %v; want ErrNonCanonical", got, err)
+			}
+		})
+	}
+
+	signed := &SignedTx{Tx: canonical, PubKey: bytes.Repeat([]byte{1}, 32), Signature: bytes.Repeat([]byte{2}, 64), Nonce: 7}
+	bytesField := func(num protowire.Number, v []byte) []byte {
+		return protowire.AppendBytes(protowire.AppendTag(nil, num, protowire.BytesType), v)
+	}
+	envelope := join(bytesField(1, signed.Tx), bytesField(2, signed.PubKey), bytesField(3, signed.Signature), varint(4, 7))
+	if !bytes.Equal(envelope, MarshalSignedTx(signed)) {
+		t.Fatal("test envelope does not match MarshalSignedTx")
+	}
+	if _, err := UnmarshalSignedTx(envelope); err != nil {
+		t.Fatalf("canonical signed tx rejected: %v", err)
+	}
+	signedTests := []struct {
+		name string
+		bz   []byte
+	}{
+		{"duplicate nonce", join(envelope, varint(4, 7))},
+		{"nonce before the tx", join(varint(4, 7), bytesField(1, signed.Tx), bytesField(2, signed.PubKey), bytesField(3, signed.Signature))},
+		{"non-minimal varint", join(bytesField(1, signed.Tx), bytesField(2, signed.PubKey), bytesField(3, signed.Signature), protowire.AppendTag(nil, 4, protowire.VarintType), []byte{0x87, 0x00})},
+		{"unknown field", join(envelope, varint(5, 1))},
+	}
+	for _, tt := range signedTests {
+		t.Run("SignedTx/"+tt.name, func(t *testing.T) {
+			if got, err := UnmarshalSignedTx(tt.bz); !errors.Is(err, ErrNonCanonical) {
+				t.Fatalf("UnmarshalSignedTx = %+v, %v; want ErrNonCanonical", got, err)
+			}
+		})
+	}
+}
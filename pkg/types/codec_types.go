@@ -0,0 +1,829 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// 각 타입의 필드 번호는 한 번 정하면 바꾸지 않습니다. 필드를 없앨 때는 번호를 재사용하지 마세요.
+// 스키마는 types.proto에 있습니다. 필드를 바꾸면 그 파일도 함께 고쳐야 합니다.
+
+// MarshalTxData는 TxData를 protobuf 와이어 형식으로 인코딩합니다.
+func MarshalTxData(tx *TxData) []byte {
+	var e protoEncoder
+	encodeTxData(&e, tx)
+	return e.buf
+}
+
+// UnmarshalTxData는 MarshalTxData로 인코딩된 바이트를 해석합니다. MarshalTxData가 만들 수 없는 바이트는 거부합니다.
+func UnmarshalTxData(bz []byte) (*TxData, error) {
+	var tx TxData
+	if err := decodeTxData(bz, &tx); err != nil {
+		return nil, fmt.Errorf("failed to decode tx data: %w", err)
+	}
+	if !bytes.Equal(MarshalTxData(&tx), bz) {
+		return nil, fmt.Errorf("failed to decode tx data: %w", ErrNonCanonical)
+	}
+	return &tx, nil
+}
+
+// MarshalSignedTx는 서명된 트랜잭션 봉투를 체인에 제출할 바이트로 인코딩합니다.
+func MarshalSignedTx(tx *SignedTx) []byte {
+	var e protoEncoder
+	e.bytes(1, tx.Tx)
+	e.bytes(2, tx.PubKey)
+	e.bytes(3, tx.Signature)
+	e.uint64(4, tx.Nonce)
+	return e.buf
+}
+
+// UnmarshalSignedTx는 체인에 제출된 트랜잭션 바이트를 봉투로 해석합니다. MarshalSignedTx가 만들 수 없는 바이트는 거부합니다.
+func UnmarshalSignedTx(bz []byte) (*SignedTx, error) {
+	var tx SignedTx
+	err := walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			tx.Tx, err = f.byteSlice()
+		case 2:
+			tx.PubKey, err = f.byteSlice()
+		case 3:
+			tx.Signature, err = f.byteSlice()
+		case 4:
+			tx.Nonce, err = f.uint64()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed tx: %w", err)
+	}
+	if !bytes.Equal(MarshalSignedTx(&tx), bz) {
+		return nil, fmt.Errorf("failed to decode signed tx: %w", ErrNonCanonical)
+	}
+	return &tx, nil
+}
+
+// MarshalAppState는 전체 상태를 인코딩합니다. 맵은 키 순서로 기록되므로 결과가 결정적입니다.
+func MarshalAppState(state *AppState) []byte {
+	var e protoEncoder
+	e.int64(1, int64(state.Version))
+	e.int64(2, state.Height)
+	e.bytes(3, state.AppHash)
+	messageMap(&e, 4, state.Accounts, encodeAccount)
+	messageMap(&e, 5, state.Proposals, encodeProposal)
+	messageMap(&e, 6, state.Politicians, encodePolitician)
+	messageMap(&e, 7, state.Orders, encodeTradeOrder)
+	// 필드 8(escrow_accounts)은 계정마다 Account.EscrowAccount로 관리하도록 바뀌어 더 이상 사용하지 않습니다.
+	messageMap(&e, 9, state.Trades, encodeTrade)
+	e.int64(10, state.OrderSequence)
+	if state.GovParams != nil {
+		e.message(11, func(e *protoEncoder) { encodeGovParams(e, state.GovParams) })
+	}
+	if state.AuthParams != nil {
+		e.message(12, func(e *protoEncoder) { encodeAuthParams(e, state.AuthParams) })
+	}
+	return e.buf
+}
+
+// UnmarshalAppState는 MarshalAppState로 인코딩된 상태를 해석합니다.
+func UnmarshalAppState(bz []byte) (*AppState, error) {
+	state := AppState{
+		Accounts:    make(map[string]*Account),
+		Proposals:   make(map[string]*Proposal),
+		Politicians: make(map[string]*Politician),
+		Orders:      make(map[string]*TradeOrder),
+		Trades:      make(map[string]*Trade),
+	}
+	err := walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			var version int64
+			version, err = f.int64()
+			state.Version = int(version)
+		case 2:
+			state.Height, err = f.int64()
+		case 3:
+			state.AppHash, err = f.byteSlice()
+		case 4:
+			err = decodeMessageEntry(f, state.Accounts, decodeAccount)
+		case 5:
+			err = decodeMessageEntry(f, state.Proposals, decodeProposal)
+		case 6:
+			err = decodeMessageEntry(f, state.Politicians, decodePolitician)
+		case 7:
+			err = decodeMessageEntry(f, state.Orders, decodeTradeOrder)
+		case 9:
+			err = decodeMessageEntry(f, state.Trades, decodeTrade)
+		case 10:
+			state.OrderSequence, err = f.int64()
+		case 11:
+			state.GovParams = &GovParams{}
+			err = f.message(func(bz []byte) error { return decodeGovParams(bz, state.GovParams) })
+		case 12:
+			state.AuthParams = &AuthParams{}
+			err = f.message(func(bz []byte) error { return decodeAuthParams(bz, state.AuthParams) })
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode app state: %w", err)
+	}
+	return &state, nil
+}
+
+// --- 트랜잭션 ---
+
+func encodeTxData(e *protoEncoder, tx *TxData) {
+	e.string(1, tx.TxID)
+	e.string(2, tx.Action)
+	e.string(3, tx.UserID)
+	e.string(4, tx.Email)
+	e.string(5, tx.WalletAddress)
+	e.string(6, tx.PoliticianName)
+	e.string(7, tx.Region)
+	e.string(8, tx.Party)
+	e.string(9, tx.IntroUrl)
+	e.strings(10, tx.Politicians)
+	e.string(11, tx.ProposalID)
+	e.bool(12, tx.Vote)
+	e.string(13, tx.Referrer)
+	if tx.Msg != nil {
+		e.message(14, func(m *protoEncoder) { encodeTxMsg(m, tx.Msg) })
+	}
+	e.string(15, string(tx.TallyMode))
+}
+
+func decodeTxData(bz []byte, tx *TxData) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		var s string
+		switch f.num {
+		case 1:
+			tx.TxID, err = f.string()
+		case 2:
+			tx.Action, err = f.string()
+		case 3:
+			tx.UserID, err = f.string()
+		case 4:
+			tx.Email, err = f.string()
+		case 5:
+			tx.WalletAddress, err = f.string()
+		case 6:
+			tx.PoliticianName, err = f.string()
+		case 7:
+			tx.Region, err = f.string()
+		case 8:
+			tx.Party, err = f.string()
+		case 9:
+			tx.IntroUrl, err = f.string()
+		case 10:
+			s, err = f.string()
+			tx.Politicians = append(tx.Politicians, s)
+		case 11:
+			tx.ProposalID, err = f.string()
+		case 12:
+			tx.Vote, err = f.bool()
+		case 13:
+			tx.Referrer, err = f.string()
+		case 14:
+			tx.Msg = &TxMsg{}
+			err = f.message(func(bz []byte) error { return decodeTxMsg(bz, tx.Msg) })
+		case 15:
+			s, err = f.string()
+			tx.TallyMode = TallyMode(s)
+		}
+		return err
+	})
+}
+
+// 필드 3(freeze_escrow)은 place_order가 에스크로를 함께 동결하도록 바뀌어 더 이상 사용하지 않습니다.
+func encodeTxMsg(e *protoEncoder, m *TxMsg) {
+	if msg := m.PlaceOrder; msg != nil {
+		// 필드 1(order_id)과 7(created_at)은 체인이 정하도록 바뀌어 더 이상 사용하지 않습니다.
+		e.message(1, func(e *protoEncoder) {
+			e.string(2, string(msg.PoliticianID))
+			e.string(3, msg.OrderType)
+			e.string(4, msg.Currency)
+			e.int64(5, msg.Quantity)
+			e.int64(6, msg.Price)
+			e.string(8, msg.Kind)
+			e.string(9, msg.TimeInForce)
+			e.bool(10, msg.PostOnly)
+			e.int64(11, msg.MaxSlippageBps)
+			e.int64(12, msg.ExpiresHeight)
+			e.int64(13, msg.ExpiresAt)
+		})
+	}
+	if msg := m.CancelOrder; msg != nil {
+		e.message(2, func(e *protoEncoder) { e.string(1, msg.OrderID) })
+	}
+	if msg := m.ReleaseEscrow; msg != nil {
+		e.message(4, func(e *protoEncoder) { e.string(1, msg.OrderID) })
+	}
+	if msg := m.Deposit; msg != nil {
+		e.message(5, func(e *protoEncoder) {
+			e.string(1, msg.Token)
+			e.int64(2, msg.Amount)
+			e.string(3, msg.TxHash)
+			e.string(4, msg.FromAddress)
+		})
+	}
+	if msg := m.Withdraw; msg != nil {
+		e.message(6, func(e *protoEncoder) {
+			e.string(1, msg.Token)
+			e.int64(2, msg.Amount)
+			e.string(3, msg.ToAddress)
+		})
+	}
+	if msg := m.BindKey; msg != nil {
+		e.message(7, func(e *protoEncoder) { e.bytes(1, msg.PubKey) })
+	}
+	if msg := m.CreateAccount; msg != nil {
+		e.message(8, func(e *protoEncoder) { e.bytes(1, msg.PubKey) })
+	}
+	if msg := m.ProposeAmendment; msg != nil {
+		e.message(9, func(e *protoEncoder) {
+			e.string(1, string(msg.PoliticianID))
+			e.string(2, msg.Region)
+			e.string(3, msg.Party)
+			e.string(4, msg.IntroUrl)
+		})
+	}
+	if msg := m.ProposeDelisting; msg != nil {
+		e.message(10, func(e *protoEncoder) { e.string(1, string(msg.PoliticianID)) })
+	}
+	if msg := m.WithdrawProposal; msg != nil {
+		e.message(11, func(e *protoEncoder) { e.string(1, msg.ProposalID) })
+	}
+}
+
+func decodeTxMsg(bz []byte, m *TxMsg) error {
+	return walkFields(bz, func(f protoField) error {
+		switch f.num {
+		case 1:
+			m.PlaceOrder = &PlaceOrderMsg{}
+			return f.message(func(bz []byte) error { return decodePlaceOrderMsg(bz, m.PlaceOrder) })
+		case 2:
+			m.CancelOrder = &CancelOrderMsg{}
+			return f.message(func(bz []byte) error { return decodeOrderID(bz, &m.CancelOrder.OrderID) })
+		case 4:
+			m.ReleaseEscrow = &ReleaseEscrowMsg{}
+			return f.message(func(bz []byte) error { return decodeOrderID(bz, &m.ReleaseEscrow.OrderID) })
+		case 5:
+			m.Deposit = &DepositMsg{}
+			return f.message(func(bz []byte) error { return decodeDepositMsg(bz, m.Deposit) })
+		case 6:
+			m.Withdraw = &WithdrawMsg{}
+			return f.message(func(bz []byte) error { return decodeWithdrawMsg(bz, m.Withdraw) })
+		case 7:
+			m.BindKey = &BindKeyMsg{}
+			return f.message(func(bz []byte) error { return decodeAuthKey(bz, &m.BindKey.PubKey) })
+		case 8:
+			m.CreateAccount = &CreateAccountMsg{}
+			return f.message(func(bz []byte) error { return decodeAuthKey(bz, &m.CreateAccount.PubKey) })
+		case 9:
+			m.ProposeAmendment = &ProposeAmendmentMsg{}
+			return f.message(func(bz []byte) error { return decodeProposeAmendmentMsg(bz, m.ProposeAmendment) })
+		case 10:
+			m.ProposeDelisting = &ProposeDelistingMsg{}
+			return f.message(func(bz []byte) error { return decodeProposalTarget(bz, &m.ProposeDelisting.PoliticianID) })
+		case 11:
+			m.WithdrawProposal = &WithdrawProposalMsg{}
+			return f.message(func(bz []byte) error { return decodeOrderID(bz, &m.WithdrawProposal.ProposalID) })
+		}
+		return nil
+	})
+}
+
+func decodePlaceOrderMsg(bz []byte, msg *PlaceOrderMsg) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 2:
+			msg.PoliticianID, err = f.politicianID()
+		case 3:
+			msg.OrderType, err = f.string()
+		case 4:
+			msg.Currency, err = f.string()
+		case 5:
+			msg.Quantity, err = f.int64()
+		case 6:
+			msg.Price, err = f.int64()
+		case 8:
+			msg.Kind, err = f.string()
+		case 9:
+			msg.TimeInForce, err = f.string()
+		case 10:
+			msg.PostOnly, err = f.bool()
+		case 11:
+			msg.MaxSlippageBps, err = f.int64()
+		case 12:
+			msg.ExpiresHeight, err = f.int64()
+		case 13:
+			msg.ExpiresAt, err = f.int64()
+		}
+		return err
+	})
+}
+
+// decodeOrderID는 필드 1 하나에 ID 문자열을 담는 메시지(CancelOrderMsg, ReleaseEscrowMsg, WithdrawProposalMsg)를 해석합니다.
+func decodeOrderID(bz []byte, orderID *string) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		if f.num == 1 {
+			*orderID, err = f.string()
+		}
+		return err
+	})
+}
+
+// decodeAuthKey는 필드 1 하나에 공개키를 담는 메시지(BindKeyMsg, CreateAccountMsg, AuthParams)를 해석합니다.
+func decodeAuthKey(bz []byte, pubKey *[]byte) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		if f.num == 1 {
+			*pubKey, err = f.byteSlice()
+		}
+		return err
+	})
+}
+
+func decodeDepositMsg(bz []byte, msg *DepositMsg) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			msg.Token, err = f.string()
+		case 2:
+			msg.Amount, err = f.int64()
+		case 3:
+			msg.TxHash, err = f.string()
+		case 4:
+			msg.FromAddress, err = f.string()
+		}
+		return err
+	})
+}
+
+func decodeWithdrawMsg(bz []byte, msg *WithdrawMsg) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			msg.Token, err = f.string()
+		case 2:
+			msg.Amount, err = f.int64()
+		case 3:
+			msg.ToAddress, err = f.string()
+		}
+		return err
+	})
+}
+
+func decodeProposeAmendmentMsg(bz []byte, msg *ProposeAmendmentMsg) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			msg.PoliticianID, err = f.politicianID()
+		case 2:
+			msg.Region, err = f.string()
+		case 3:
+			msg.Party, err = f.string()
+		case 4:
+			msg.IntroUrl, err = f.string()
+		}
+		return err
+	})
+}
+
+// decodeProposalTarget은 필드 1 하나에 대상 정치인 ID를 담는 메시지(ProposeDelistingMsg)를 해석합니다.
+func decodeProposalTarget(bz []byte, id *PoliticianID) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		if f.num == 1 {
+			*id, err = f.politicianID()
+		}
+		return err
+	})
+}
+
+// --- 상태 엔티티 ---
+
+func encodeAccount(e *protoEncoder, a *Account) {
+	e.string(1, a.Address)
+	e.string(2, a.Email)
+	e.string(3, a.Wallet)
+	e.strings(4, a.Politicians)
+	e.int64(5, int64(a.ReferralCredits))
+	e.int64Map(6, a.PoliticianCoins)
+	e.boolMap(7, a.ReceivedCoins)
+	e.bool(8, a.InitialSelection)
+	e.int64(9, a.USDTBalance)
+	e.int64(10, a.USDCBalance)
+	e.int64(11, a.MATICBalance)
+	e.string(12, a.PolygonWalletAddress)
+	for i := range a.ActiveOrders {
+		order := &a.ActiveOrders[i]
+		e.message(13, func(e *protoEncoder) { encodeTradeOrder(e, order) })
+	}
+	e.message(14, func(e *protoEncoder) { encodeEscrowAccount(e, &a.EscrowAccount) })
+	e.bytes(15, a.PubKey)
+	e.uint64(16, a.Nonce)
+	e.int64(17, a.CreatedHeight)
+}
+
+func decodeAccount(bz []byte, a *Account) error {
+	// JSON 시절과 같이 빈 목록과 맵은 nil이 아닌 빈 값으로 둡니다.
+	a.Politicians = []string{}
+	a.PoliticianCoins = make(map[string]int64)
+	a.ReceivedCoins = make(map[string]bool)
+	a.ActiveOrders = []TradeOrder{}
+	a.EscrowAccount.FrozenPoliticianCoins = make(map[string]int64)
+	a.EscrowAccount.ActiveOrders = []string{}
+	return walkFields(bz, func(f protoField) (err error) {
+		var s string
+		var n int64
+		switch f.num {
+		case 1:
+			a.Address, err = f.string()
+		case 2:
+			a.Email, err = f.string()
+		case 3:
+			a.Wallet, err = f.string()
+		case 4:
+			s, err = f.string()
+			a.Politicians = append(a.Politicians, s)
+		case 5:
+			n, err = f.int64()
+			a.ReferralCredits = int(n)
+		case 6:
+			err = decodeInt64Entry(f, a.PoliticianCoins)
+		case 7:
+			err = decodeBoolEntry(f, a.ReceivedCoins)
+		case 8:
+			a.InitialSelection, err = f.bool()
+		case 9:
+			a.USDTBalance, err = f.int64()
+		case 10:
+			a.USDCBalance, err = f.int64()
+		case 11:
+			a.MATICBalance, err = f.int64()
+		case 12:
+			a.PolygonWalletAddress, err = f.string()
+		case 13:
+			var order TradeOrder
+			err = f.message(func(bz []byte) error { return decodeTradeOrder(bz, &order) })
+			a.ActiveOrders = append(a.ActiveOrders, order)
+		case 14:
+			err = f.message(func(bz []byte) error { return decodeEscrowAccount(bz, &a.EscrowAccount) })
+		case 15:
+			a.PubKey, err = f.byteSlice()
+		case 16:
+			a.Nonce, err = f.uint64()
+		case 17:
+			a.CreatedHeight, err = f.int64()
+		}
+		return err
+	})
+}
+
+func encodePolitician(e *protoEncoder, p *Politician) {
+	e.string(1, p.Name)
+	e.string(2, p.Region)
+	e.string(3, p.Party)
+	e.string(4, p.IntroUrl)
+	e.strings(5, p.Supporters)
+	e.int64(6, p.TotalCoinSupply)
+	e.int64(7, p.RemainingCoins)
+	e.int64(8, p.DistributedCoins)
+	e.string(9, string(p.ID))
+	e.bool(10, p.Delisted)
+	e.int64(11, p.DelistedHeight)
+}
+
+func decodePolitician(bz []byte, p *Politician) error {
+	p.Supporters = []string{}
+	return walkFields(bz, func(f protoField) (err error) {
+		var s string
+		switch f.num {
+		case 1:
+			p.Name, err = f.string()
+		case 2:
+			p.Region, err = f.string()
+		case 3:
+			p.Party, err = f.string()
+		case 4:
+			p.IntroUrl, err = f.string()
+		case 5:
+			s, err = f.string()
+			p.Supporters = append(p.Supporters, s)
+		case 6:
+			p.TotalCoinSupply, err = f.int64()
+		case 7:
+			p.RemainingCoins, err = f.int64()
+		case 8:
+			p.DistributedCoins, err = f.int64()
+		case 9:
+			p.ID, err = f.politicianID()
+		case 10:
+			p.Delisted, err = f.bool()
+		case 11:
+			p.DelistedHeight, err = f.int64()
+		}
+		return err
+	})
+}
+
+func encodeProposal(e *protoEncoder, p *Proposal) {
+	e.string(1, p.ID)
+	e.message(2, func(e *protoEncoder) { encodePolitician(e, &p.Politician) })
+	e.string(3, p.Proposer)
+	e.boolMap(4, p.Votes)
+	e.int64(5, int64(p.YesVotes))
+	e.int64(6, int64(p.NoVotes))
+	e.int64(7, p.SubmitHeight)
+	e.int64(8, p.VotingEndHeight)
+	e.int64(9, p.Deposit)
+	e.string(10, string(p.TallyMode))
+	e.string(11, p.Status)
+	e.int64(12, p.ClosedHeight)
+	e.string(13, p.Type)
+}
+
+func decodeProposal(bz []byte, p *Proposal) error {
+	p.Votes = make(map[string]bool)
+	p.Politician.Supporters = []string{}
+	return walkFields(bz, func(f protoField) (err error) {
+		var n int64
+		switch f.num {
+		case 1:
+			p.ID, err = f.string()
+		case 2:
+			err = f.message(func(bz []byte) error { return decodePolitician(bz, &p.Politician) })
+		case 3:
+			p.Proposer, err = f.string()
+		case 4:
+			err = decodeBoolEntry(f, p.Votes)
+		case 5:
+			n, err = f.int64()
+			p.YesVotes = int(n)
+		case 6:
+			n, err = f.int64()
+			p.NoVotes = int(n)
+		case 7:
+			p.SubmitHeight, err = f.int64()
+		case 8:
+			p.VotingEndHeight, err = f.int64()
+		case 9:
+			p.Deposit, err = f.int64()
+		case 10:
+			var s string
+			s, err = f.string()
+			p.TallyMode = TallyMode(s)
+		case 11:
+			p.Status, err = f.string()
+		case 12:
+			p.ClosedHeight, err = f.int64()
+		case 13:
+			p.Type, err = f.string()
+		}
+		return err
+	})
+}
+
+func encodeTradeOrder(e *protoEncoder, o *TradeOrder) {
+	e.string(1, o.ID)
+	e.string(2, o.UserID)
+	e.string(3, string(o.PoliticianID))
+	e.string(4, o.OrderType)
+	e.string(5, o.Currency)
+	e.int64(6, o.Quantity)
+	e.int64(7, o.Price)
+	e.string(8, o.Status)
+	e.int64(9, o.FilledQuantity)
+	e.int64(10, o.EscrowAmount)
+	e.int64(11, o.Sequence)
+	e.int64(12, o.CreatedAt)
+	e.int64(13, o.UpdatedAt)
+	e.string(14, o.Kind)
+	e.string(15, o.TimeInForce)
+	e.bool(16, o.PostOnly)
+	e.int64(17, o.MaxSlippageBps)
+	e.int64(18, o.ExpiresHeight)
+	e.int64(19, o.ExpiresAt)
+}
+
+func decodeTradeOrder(bz []byte, o *TradeOrder) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			o.ID, err = f.string()
+		case 2:
+			o.UserID, err = f.string()
+		case 3:
+			o.PoliticianID, err = f.politicianID()
+		case 4:
+			o.OrderType, err = f.string()
+		case 5:
+			o.Currency, err = f.string()
+		case 6:
+			o.Quantity, err = f.int64()
+		case 7:
+			o.Price, err = f.int64()
+		case 8:
+			o.Status, err = f.string()
+		case 9:
+			o.FilledQuantity, err = f.int64()
+		case 10:
+			o.EscrowAmount, err = f.int64()
+		case 11:
+			o.Sequence, err = f.int64()
+		case 12:
+			o.CreatedAt, err = f.int64()
+		case 13:
+			o.UpdatedAt, err = f.int64()
+		case 14:
+			o.Kind, err = f.string()
+		case 15:
+			o.TimeInForce, err = f.string()
+		case 16:
+			o.PostOnly, err = f.bool()
+		case 17:
+			o.MaxSlippageBps, err = f.int64()
+		case 18:
+			o.ExpiresHeight, err = f.int64()
+		case 19:
+			o.ExpiresAt, err = f.int64()
+		}
+		return err
+	})
+}
+
+func encodeEscrowAccount(e *protoEncoder, a *EscrowAccount) {
+	e.string(1, a.UserID)
+	e.int64(2, a.FrozenUSDTBalance)
+	e.int64(3, a.FrozenUSDCBalance)
+	e.int64Map(4, a.FrozenPoliticianCoins)
+	e.strings(5, a.ActiveOrders)
+}
+
+func decodeEscrowAccount(bz []byte, a *EscrowAccount) error {
+	a.FrozenPoliticianCoins = make(map[string]int64)
+	a.ActiveOrders = []string{}
+	return walkFields(bz, func(f protoField) (err error) {
+		var s string
+		switch f.num {
+		case 1:
+			a.UserID, err = f.string()
+		case 2:
+			a.FrozenUSDTBalance, err = f.int64()
+		case 3:
+			a.FrozenUSDCBalance, err = f.int64()
+		case 4:
+			err = decodeInt64Entry(f, a.FrozenPoliticianCoins)
+		case 5:
+			s, err = f.string()
+			a.ActiveOrders = append(a.ActiveOrders, s)
+		}
+		return err
+	})
+}
+
+func encodeTrade(e *protoEncoder, t *Trade) {
+	e.string(1, t.ID)
+	e.string(2, t.BuyOrderID)
+	e.string(3, t.SellOrderID)
+	e.string(4, t.BuyerID)
+	e.string(5, t.SellerID)
+	e.string(6, string(t.PoliticianID))
+	e.int64(7, t.Quantity)
+	e.int64(8, t.Price)
+	e.int64(9, t.TotalAmount)
+	e.int64(10, t.Timestamp)
+	e.string(11, t.Status)
+	e.string(12, t.Currency)
+}
+
+func decodeTrade(bz []byte, t *Trade) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			t.ID, err = f.string()
+		case 2:
+			t.BuyOrderID, err = f.string()
+		case 3:
+			t.SellOrderID, err = f.string()
+		case 4:
+			t.BuyerID, err = f.string()
+		case 5:
+			t.SellerID, err = f.string()
+		case 6:
+			t.PoliticianID, err = f.politicianID()
+		case 7:
+			t.Quantity, err = f.int64()
+		case 8:
+			t.Price, err = f.int64()
+		case 9:
+			t.TotalAmount, err = f.int64()
+		case 10:
+			t.Timestamp, err = f.int64()
+		case 11:
+			t.Status, err = f.string()
+		case 12:
+			t.Currency, err = f.string()
+		}
+		return err
+	})
+}
+
+func encodeGovParams(e *protoEncoder, p *GovParams) {
+	e.int64(1, p.Quorum)
+	e.int64(2, p.ApprovalThreshold)
+	e.int64(3, p.VotingPeriod)
+	e.int64(4, p.MinAccountAge)
+	e.int64(5, p.ProposerDeposit)
+}
+
+func decodeGovParams(bz []byte, p *GovParams) error {
+	return walkFields(bz, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			p.Quorum, err = f.int64()
+		case 2:
+			p.ApprovalThreshold, err = f.int64()
+		case 3:
+			p.VotingPeriod, err = f.int64()
+		case 4:
+			p.MinAccountAge, err = f.int64()
+		case 5:
+			p.ProposerDeposit, err = f.int64()
+		}
+		return err
+	})
+}
+
+func encodeAuthParams(e *protoEncoder, p *AuthParams) {
+	e.bytes(1, p.KeyRegistrar)
+}
+
+func decodeAuthParams(bz []byte, p *AuthParams) error {
+	return decodeAuthKey(bz, &p.KeyRegistrar)
+}
+
+// --- 엔티티 단위 인코딩 (상태 저장소의 키별 값) ---
+
+// MarshalAccount는 계정 하나를 인코딩합니다.
+func MarshalAccount(a *Account) []byte { return marshalWith(encodeAccount, a) }
+
+// UnmarshalAccount는 MarshalAccount로 인코딩된 계정을 해석합니다.
+func UnmarshalAccount(bz []byte) (*Account, error) { return unmarshalWith(bz, decodeAccount) }
+
+// MarshalPolitician은 정치인 하나를 인코딩합니다.
+func MarshalPolitician(p *Politician) []byte { return marshalWith(encodePolitician, p) }
+
+// UnmarshalPolitician은 MarshalPolitician으로 인코딩된 정치인을 해석합니다.
+func UnmarshalPolitician(bz []byte) (*Politician, error) { return unmarshalWith(bz, decodePolitician) }
+
+// MarshalProposal은 제안 하나를 인코딩합니다.
+func MarshalProposal(p *Proposal) []byte { return marshalWith(encodeProposal, p) }
+
+// UnmarshalProposal은 MarshalProposal로 인코딩된 제안을 해석합니다.
+func UnmarshalProposal(bz []byte) (*Proposal, error) { return unmarshalWith(bz, decodeProposal) }
+
+// MarshalTradeOrder는 주문 하나를 인코딩합니다.
+func MarshalTradeOrder(o *TradeOrder) []byte { return marshalWith(encodeTradeOrder, o) }
+
+// UnmarshalTradeOrder는 MarshalTradeOrder로 인코딩된 주문을 해석합니다.
+func UnmarshalTradeOrder(bz []byte) (*TradeOrder, error) { return unmarshalWith(bz, decodeTradeOrder) }
+
+// MarshalEscrowAccount는 에스크로 계정 하나를 인코딩합니다.
+func MarshalEscrowAccount(a *EscrowAccount) []byte { return marshalWith(encodeEscrowAccount, a) }
+
+// UnmarshalEscrowAccount는 MarshalEscrowAccount로 인코딩된 에스크로 계정을 해석합니다.
+func UnmarshalEscrowAccount(bz []byte) (*EscrowAccount, error) {
+	return unmarshalWith(bz, decodeEscrowAccount)
+}
+
+// MarshalGovParams는 거버넌스 파라미터를 인코딩합니다.
+func MarshalGovParams(p *GovParams) []byte { return marshalWith(encodeGovParams, p) }
+
+// UnmarshalGovParams는 MarshalGovParams로 인코딩된 거버넌스 파라미터를 해석합니다.
+func UnmarshalGovParams(bz []byte) (*GovParams, error) { return unmarshalWith(bz, decodeGovParams) }
+
+// MarshalAuthParams는 인증 파라미터를 인코딩합니다.
+func MarshalAuthParams(p *AuthParams) []byte { return marshalWith(encodeAuthParams, p) }
+
+// UnmarshalAuthParams는 MarshalAuthParams로 인코딩된 인증 파라미터를 해석합니다.
+func UnmarshalAuthParams(bz []byte) (*AuthParams, error) { return unmarshalWith(bz, decodeAuthParams) }
+
+// MarshalTrade는 체결 기록 하나를 인코딩합니다.
+func MarshalTrade(t *Trade) []byte { return marshalWith(encodeTrade, t) }
+
+// UnmarshalTrade는 MarshalTrade로 인코딩된 체결 기록을 해석합니다.
+func UnmarshalTrade(bz []byte) (*Trade, error) { return unmarshalWith(bz, decodeTrade) }
+
+func marshalWith[T any](encode func(*protoEncoder, *T), v *T) []byte {
+	var e protoEncoder
+	encode(&e, v)
+	return e.buf
+}
+
+func unmarshalWith[T any](bz []byte, decode func([]byte, *T) error) (*T, error) {
+	v := new(T)
+	if err := decode(bz, v); err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", v, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,240 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// validOrderStatuses는 주문이 가질 수 있는 상태입니다.
+var validOrderStatuses = map[string]bool{"active": true, "partial": true, "filled": true, "cancelled": true, "killed": true, "expired": true}
+
+// Validate는 제네시스 상태가 스스로 모순이 없는지 확인합니다.
+// 맵의 키와 엔티티의 ID가 같아야 하고, 주문·에스크로·거래가 참조하는 계정과 정치인, 주문이 존재해야 하며,
+// 계정의 동결 잔액은 미체결 주문에 잠긴 금액과 같아야 합니다.
+func (gs *GenesisState) Validate() error {
+	for key, account := range gs.Accounts {
+		if account == nil || key == "" || account.Address != key {
+			return fmt.Errorf("account %q: address must match its key", key)
+		}
+		if account.USDTBalance < 0 || account.USDCBalance < 0 || account.MATICBalance < 0 {
+			return fmt.Errorf("account %q: balances must not be negative", key)
+		}
+		for politicianID, amount := range account.PoliticianCoins {
+			if amount < 0 {
+				return fmt.Errorf("account %q: negative %s coin balance", key, politicianID)
+			}
+			if _, exists := gs.Politicians[politicianID]; !exists {
+				return fmt.Errorf("account %q: coins of unknown politician %q", key, politicianID)
+			}
+		}
+	}
+	for key, politician := range gs.Politicians {
+		if politician == nil || string(politician.ID) != key {
+			return fmt.Errorf("politician %q: id must match its key", key)
+		}
+		if err := politician.ID.Validate(); err != nil {
+			return fmt.Errorf("politician %q: %w", key, err)
+		}
+		if politician.Name == "" {
+			return fmt.Errorf("politician %q: name is required", key)
+		}
+		if politician.RemainingCoins < 0 || politician.DistributedCoins < 0 ||
+			politician.RemainingCoins+politician.DistributedCoins > politician.TotalCoinSupply {
+			return fmt.Errorf("politician %q: remaining and distributed coins must not exceed the total supply", key)
+		}
+	}
+	if gs.GovParams != nil {
+		if err := gs.GovParams.Validate(); err != nil {
+			return fmt.Errorf("gov_params: %w", err)
+		}
+	}
+	if gs.AuthParams != nil {
+		if err := gs.AuthParams.Validate(); err != nil {
+			return fmt.Errorf("auth_params: %w", err)
+		}
+	}
+	for key, proposal := range gs.Proposals {
+		if proposal == nil || key == "" || proposal.ID != key {
+			return fmt.Errorf("proposal %q: id must match its key", key)
+		}
+		if proposal.Proposer == "" {
+			return fmt.Errorf("proposal %q: proposer is required", key)
+		}
+		if proposal.Deposit < 0 || proposal.VotingEndHeight < proposal.SubmitHeight {
+			return fmt.Errorf("proposal %q: invalid deposit or voting period", key)
+		}
+		if proposal.Type != "" && !ValidProposalType(proposal.Type) {
+			return fmt.Errorf("proposal %q: unknown type %q", key, proposal.Type)
+		}
+		if proposal.Type == ProposalTypeAmend || proposal.Type == ProposalTypeDelist {
+			if _, exists := gs.Politicians[string(proposal.Politician.ID)]; !exists {
+				return fmt.Errorf("proposal %q: %s of unknown politician %q", key, proposal.Type, proposal.Politician.ID)
+			}
+		}
+		if proposal.Status != "" && !ValidProposalStatus(proposal.Status) {
+			return fmt.Errorf("proposal %q: unknown status %q", key, proposal.Status)
+		}
+		if proposal.TallyMode != "" {
+			if err := proposal.TallyMode.Validate(); err != nil {
+				return fmt.Errorf("proposal %q: %w", key, err)
+			}
+		}
+	}
+	for key, user := range gs.Users {
+		if user == nil || key == "" || user.ID != key {
+			return fmt.Errorf("user %q: id must match its key", key)
+		}
+	}
+
+	if gs.OrderSequence < 0 {
+		return fmt.Errorf("order_sequence must not be negative")
+	}
+	orders := make(map[string]bool, len(gs.Orders))
+	sequences := make(map[int64]bool, len(gs.Orders))
+	for i := range gs.Orders {
+		order := &gs.Orders[i]
+		if order.ID == "" || orders[order.ID] {
+			return fmt.Errorf("order %d: id %q is empty or duplicated", i, order.ID)
+		}
+		orders[order.ID] = true
+		if _, exists := gs.Accounts[order.UserID]; !exists {
+			return fmt.Errorf("order %q: account %q not found", order.ID, order.UserID)
+		}
+		if _, exists := gs.Politicians[string(order.PoliticianID)]; !exists {
+			return fmt.Errorf("order %q: politician %q not found", order.ID, order.PoliticianID)
+		}
+		if order.OrderType != "buy" && order.OrderType != "sell" {
+			return fmt.Errorf("order %q: order_type must be buy or sell", order.ID)
+		}
+		if err := validateCurrency(order.Currency); err != nil {
+			return fmt.Errorf("order %q: %w", order.ID, err)
+		}
+		// 슬리피지를 100% 허용한 시장가 매도 주문은 체결 한계 가격이 0일 수 있습니다.
+		if order.Quantity <= 0 || order.Price < 0 || (order.Price == 0 && order.Kind != OrderKindMarket) || order.FilledQuantity < 0 || order.FilledQuantity > order.Quantity {
+			return fmt.Errorf("order %q: invalid quantity, price or filled quantity", order.ID)
+		}
+		if order.Kind != "" && order.Kind != OrderKindLimit && order.Kind != OrderKindMarket {
+			return fmt.Errorf("order %q: unknown kind %q", order.ID, order.Kind)
+		}
+		switch order.TimeInForce {
+		case "", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK:
+		default:
+			return fmt.Errorf("order %q: unknown time_in_force %q", order.ID, order.TimeInForce)
+		}
+		if err := validateOrderExpiry(order.Kind, order.TimeInForce, order.ExpiresHeight, order.ExpiresAt); err != nil {
+			return fmt.Errorf("order %q: %w", order.ID, err)
+		}
+		if !validOrderStatuses[order.Status] {
+			return fmt.Errorf("order %q: unknown status %q", order.ID, order.Status)
+		}
+		if order.Sequence <= 0 || order.Sequence > gs.OrderSequence || sequences[order.Sequence] {
+			return fmt.Errorf("order %q: sequence %d must be unique and between 1 and order_sequence", order.ID, order.Sequence)
+		}
+		sequences[order.Sequence] = true
+		// 미체결 주문은 접수할 때 에스크로가 동결되므로 계정의 활성 주문 목록에 있어야 합니다.
+		if (order.Status == "active" || order.Status == "partial") && order.TimeInForce != "" && order.TimeInForce != TimeInForceGTC {
+			return fmt.Errorf("order %q: only GTC orders can stay open", order.ID)
+		}
+		if (order.Status == "active" || order.Status == "partial") && !slices.Contains(gs.Accounts[order.UserID].EscrowAccount.ActiveOrders, order.ID) {
+			return fmt.Errorf("order %q: open order has no escrow locked in account %q", order.ID, order.UserID)
+		}
+	}
+	if err := gs.validateEscrow(); err != nil {
+		return err
+	}
+
+	trades := make(map[string]bool, len(gs.Trades))
+	for i := range gs.Trades {
+		trade := &gs.Trades[i]
+		if trade.ID == "" || trades[trade.ID] {
+			return fmt.Errorf("trade %d: id %q is empty or duplicated", i, trade.ID)
+		}
+		trades[trade.ID] = true
+		if _, exists := gs.Accounts[trade.BuyerID]; !exists {
+			return fmt.Errorf("trade %q: buyer %q not found", trade.ID, trade.BuyerID)
+		}
+		if _, exists := gs.Accounts[trade.SellerID]; !exists {
+			return fmt.Errorf("trade %q: seller %q not found", trade.ID, trade.SellerID)
+		}
+		if _, exists := gs.Politicians[string(trade.PoliticianID)]; !exists {
+			return fmt.Errorf("trade %q: politician %q not found", trade.ID, trade.PoliticianID)
+		}
+		if trade.Quantity <= 0 || trade.Price <= 0 {
+			return fmt.Errorf("trade %q: quantity and price must be positive", trade.ID)
+		}
+		if trade.Currency != "" {
+			if err := validateCurrency(trade.Currency); err != nil {
+				return fmt.Errorf("trade %q: %w", trade.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateEscrow는 계정마다 동결 잔액이 그 계정의 미체결 주문에 잠긴 금액의 합과 같은지 확인합니다.
+// 미체결 주문은 남은 수량만큼 잠가 둡니다: 매수는 남은 수량 × 가격의 결제 통화, 매도는 남은 수량의 정치인 코인입니다.
+// 에스크로의 활성 주문 목록에는 그 계정의 미체결 주문만 한 번씩 있어야 합니다.
+// 주문 자체의 검사(계정 존재, 수량, 상태)는 Validate에서 먼저 끝난 것으로 봅니다.
+func (gs *GenesisState) validateEscrow() error {
+	open := make(map[string]*TradeOrder)
+	locked := make(map[string]*EscrowAccount)
+	for i := range gs.Orders {
+		order := &gs.Orders[i]
+		if order.Status != "active" && order.Status != "partial" {
+			continue
+		}
+		open[order.ID] = order
+		want, ok := locked[order.UserID]
+		if !ok {
+			want = &EscrowAccount{FrozenPoliticianCoins: make(map[string]int64)}
+			locked[order.UserID] = want
+		}
+		remaining := order.Quantity - order.FilledQuantity
+		if order.OrderType == "sell" {
+			want.FrozenPoliticianCoins[string(order.PoliticianID)] += remaining
+			continue
+		}
+		if order.Price > 0 && remaining > math.MaxInt64/order.Price {
+			return fmt.Errorf("order %q: locked amount overflows", order.ID)
+		}
+		if order.Currency == "USDC" {
+			want.FrozenUSDCBalance += remaining * order.Price
+		} else {
+			want.FrozenUSDTBalance += remaining * order.Price
+		}
+	}
+
+	for key, account := range gs.Accounts {
+		escrow := &account.EscrowAccount
+		want, ok := locked[key]
+		if !ok {
+			want = &EscrowAccount{}
+		}
+		if escrow.FrozenUSDTBalance != want.FrozenUSDTBalance || escrow.FrozenUSDCBalance != want.FrozenUSDCBalance {
+			return fmt.Errorf("account %q: frozen USDT %d, USDC %d do not match the %d USDT, %d USDC locked by open orders",
+				key, escrow.FrozenUSDTBalance, escrow.FrozenUSDCBalance, want.FrozenUSDTBalance, want.FrozenUSDCBalance)
+		}
+		for politicianID, amount := range escrow.FrozenPoliticianCoins {
+			if amount != want.FrozenPoliticianCoins[politicianID] {
+				return fmt.Errorf("account %q: frozen %s coins %d do not match the %d locked by open orders",
+					key, politicianID, amount, want.FrozenPoliticianCoins[politicianID])
+			}
+		}
+		for politicianID, amount := range want.FrozenPoliticianCoins {
+			if amount != escrow.FrozenPoliticianCoins[politicianID] {
+				return fmt.Errorf("account %q: frozen %s coins %d do not match the %d locked by open orders",
+					key, politicianID, escrow.FrozenPoliticianCoins[politicianID], amount)
+			}
+		}
+		seen := make(map[string]bool, len(escrow.ActiveOrders))
+		for _, orderID := range escrow.ActiveOrders {
+			order, isOpen := open[orderID]
+			if !isOpen || order.UserID != key || seen[orderID] {
+				return fmt.Errorf("account %q: escrow lists %q, which is not an open order of this account or is listed twice", key, orderID)
+			}
+			seen[orderID] = true
+		}
+	}
+	return nil
+}
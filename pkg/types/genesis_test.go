@@ -0,0 +1,98 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+// escrowGenesis는 매수 주문 하나(USDT, 남은 수량 3 × 가격 100)와 매도 주문 하나(남은 코인 2)가 열려 있고,
+// 동결 잔액이 두 주문에 잠긴 금액과 같은 제네시스를 만듭니다.
+func escrowGenesis() *GenesisState {
+	politician := &Politician{ID: NewPoliticianID("홍길동", "서울"), Name: "홍길동", Region: "서울", TotalCoinSupply: 100}
+	return &GenesisState{
+		Politicians: map[string]*Politician{string(politician.ID): politician},
+		Accounts: map[string]*Account{
+			"alice": {
+				Address:         "alice",
+				USDTBalance:     1_000,
+				PoliticianCoins: map[string]int64{string(politician.ID): 10},
+				EscrowAccount: EscrowAccount{
+					UserID:                "alice",
+					FrozenUSDTBalance:     300,
+					FrozenPoliticianCoins: map[string]int64{string(politician.ID): 2},
+					ActiveOrders:          []string{"buy-1", "sell-1"},
+				},
+			},
+			"bob": {Address: "bob"},
+		},
+		Orders: []TradeOrder{
+			{ID: "buy-1", UserID: "alice", PoliticianID: politician.ID, OrderType: "buy", Currency: "USDT", Quantity: 5, FilledQuantity: 2, Price: 100, Status: "partial", Sequence: 1},
+			{ID: "sell-1", UserID: "alice", PoliticianID: politician.ID, OrderType: "sell", Currency: "USDT", Quantity: 2, Price: 120, Status: "active", Sequence: 2},
+			{ID: "done-1", UserID: "alice", PoliticianID: politician.ID, OrderType: "buy", Currency: "USDT", Quantity: 1, FilledQuantity: 1, Price: 90, Status: "filled", Sequence: 3},
+		},
+		OrderSequence: 3,
+	}
+}
+
+func TestGenesisEscrowMatchesOpenOrders(t *testing.T) {
+	coinID := string(NewPoliticianID("홍길동", "서울"))
+	tests := []struct {
+		name    string
+		mutate  func(gs *GenesisState)
+		wantErr string
+	}{
+		{name: "frozen amounts match open orders", mutate: func(gs *GenesisState) {}},
+		{name: "frozen USDT above open orders", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.FrozenUSDTBalance = 301
+		}, wantErr: "frozen USDT"},
+		{name: "frozen USDT of a filled order", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.FrozenUSDTBalance = 500
+		}, wantErr: "frozen USDT"},
+		{name: "frozen USDC without orders", mutate: func(gs *GenesisState) {
+			gs.Accounts["bob"].EscrowAccount.FrozenUSDCBalance = 1
+		}, wantErr: `account "bob"`},
+		{name: "frozen coins below open orders", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.FrozenPoliticianCoins[coinID] = 1
+		}, wantErr: "frozen " + coinID},
+		{name: "frozen coins missing", mutate: func(gs *GenesisState) {
+			delete(gs.Accounts["alice"].EscrowAccount.FrozenPoliticianCoins, coinID)
+		}, wantErr: "frozen " + coinID},
+		{name: "frozen coins of another politician", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.FrozenPoliticianCoins["other"] = 1
+		}, wantErr: "frozen other"},
+		{name: "zero frozen entry is allowed", mutate: func(gs *GenesisState) {
+			gs.Accounts["bob"].EscrowAccount.FrozenPoliticianCoins = map[string]int64{coinID: 0}
+		}},
+		{name: "escrow lists a closed order", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.ActiveOrders = append(gs.Accounts["alice"].EscrowAccount.ActiveOrders, "done-1")
+		}, wantErr: `lists "done-1"`},
+		{name: "escrow lists an order twice", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.ActiveOrders = append(gs.Accounts["alice"].EscrowAccount.ActiveOrders, "buy-1")
+		}, wantErr: `lists "buy-1"`},
+		{name: "escrow lists another account's order", mutate: func(gs *GenesisState) {
+			gs.Accounts["bob"].EscrowAccount.ActiveOrders = []string{"sell-1"}
+		}, wantErr: `lists "sell-1"`},
+		{name: "open order not locked", mutate: func(gs *GenesisState) {
+			gs.Accounts["alice"].EscrowAccount.ActiveOrders = []string{"buy-1"}
+		}, wantErr: "no escrow locked"},
+		{name: "locked amount overflows", mutate: func(gs *GenesisState) {
+			gs.Orders[0].Quantity, gs.Orders[0].FilledQuantity, gs.Orders[0].Price = 1<<40, 0, 1<<40
+		}, wantErr: "overflows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := escrowGenesis()
+			tt.mutate(gs)
+			err := gs.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
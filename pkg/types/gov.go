@@ -0,0 +1,97 @@
+package types
+
+import "fmt"
+
+// GovParams는 정치인 등록 제안의 거버넌스 파라미터입니다.
+// 상태에 저장되어 앱 해시에 포함되며, 제네시스의 gov_params로 정합니다.
+type GovParams struct {
+	Quorum            int64 `json:"quorum"`             // 집계가 유효하기 위한 최소 투표 계정 수
+	ApprovalThreshold int64 `json:"approval_threshold"` // 가결 기준 찬성 비율 (%). 찬성 / (찬성 + 반대)가 이 값을 넘어야 가결
+	VotingPeriod      int64 `json:"voting_period"`      // 제안 후 투표 기간 (블록 수)
+	MinAccountAge     int64 `json:"min_account_age"`    // 제안하거나 투표하려면 계정이 만들어진 뒤 지나야 하는 블록 수
+	ProposerDeposit   int64 `json:"proposer_deposit"`   // 제안자가 USDT 잔액에서 맡기는 보증금 (6 decimal places)
+}
+
+// DefaultGovParams는 제네시스에 gov_params가 없을 때 사용하는 값입니다.
+// 기본 블록 간격(5초) 기준으로 투표 기간과 최소 계정 나이는 약 하루입니다.
+func DefaultGovParams() GovParams {
+	return GovParams{
+		Quorum:            3,
+		ApprovalThreshold: 50,
+		VotingPeriod:      17_280,
+		MinAccountAge:     17_280,
+		ProposerDeposit:   10_000_000, // 10 USDT
+	}
+}
+
+// Validate는 파라미터가 의미 있는 범위인지 확인합니다.
+func (p GovParams) Validate() error {
+	if p.Quorum < 1 {
+		return fmt.Errorf("quorum must be at least 1")
+	}
+	if p.ApprovalThreshold < 0 || p.ApprovalThreshold >= 100 {
+		return fmt.Errorf("approval_threshold must be between 0 and 99")
+	}
+	if p.VotingPeriod < 1 {
+		return fmt.Errorf("voting_period must be at least 1 block")
+	}
+	if p.MinAccountAge < 0 || p.ProposerDeposit < 0 {
+		return fmt.Errorf("min_account_age and proposer_deposit must not be negative")
+	}
+	return nil
+}
+
+// TallyMode는 제안의 투표를 집계하는 방식입니다. 제안할 때 정해 제안에 기록합니다.
+type TallyMode string
+
+const (
+	// TallyModeOneAccountOneVote는 계정마다 한 표로 셉니다. 제안에 방식이 없으면 이 방식입니다.
+	TallyModeOneAccountOneVote TallyMode = "one_account_one_vote"
+	// TallyModeCoinWeighted는 투표자가 보유한 정치인 코인 전체 수량만큼의 표로 셉니다.
+	TallyModeCoinWeighted TallyMode = "coin_weighted"
+	// TallyModeQuadratic은 보유한 정치인 코인 전체 수량의 제곱근(내림)만큼의 표로 셉니다.
+	TallyModeQuadratic TallyMode = "quadratic"
+)
+
+// Validate는 알려진 집계 방식인지 확인합니다.
+func (m TallyMode) Validate() error {
+	switch m {
+	case TallyModeOneAccountOneVote, TallyModeCoinWeighted, TallyModeQuadratic:
+		return nil
+	}
+	return fmt.Errorf("unknown tally mode %q (expected %s, %s or %s)", m, TallyModeOneAccountOneVote, TallyModeCoinWeighted, TallyModeQuadratic)
+}
+
+// 제안 상태입니다. 투표 중인 제안만 투표와 철회를 받으며, 나머지는 닫힌 제안으로 기록에 남습니다.
+const (
+	ProposalStatusVoting    = "voting"    // 투표 기간 중
+	ProposalStatusPassed    = "passed"    // 정족수를 채우고 가결되어 정치인이 등록됨
+	ProposalStatusRejected  = "rejected"  // 정족수를 채웠지만 부결되었거나, 같은 정치인이 먼저 등록됨
+	ProposalStatusExpired   = "expired"   // 투표 기간 안에 정족수를 채우지 못함
+	ProposalStatusWithdrawn = "withdrawn" // 투표 기간 중 제안자가 철회함
+)
+
+// ValidProposalStatus는 알려진 제안 상태인지 확인합니다.
+func ValidProposalStatus(status string) bool {
+	switch status {
+	case ProposalStatusVoting, ProposalStatusPassed, ProposalStatusRejected, ProposalStatusExpired, ProposalStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// 제안 종류입니다. 모든 종류가 같은 투표 기간, 정족수, 보증금 규칙으로 집계됩니다.
+const (
+	ProposalTypeRegister = "register" // 새 정치인 등록
+	ProposalTypeAmend    = "amend"    // 등록된 정치인의 지역, 정당, 소개 URL 수정
+	ProposalTypeDelist   = "delist"   // 정치인 상장 폐지
+)
+
+// ValidProposalType은 알려진 제안 종류인지 확인합니다.
+func ValidProposalType(proposalType string) bool {
+	switch proposalType {
+	case ProposalTypeRegister, ProposalTypeAmend, ProposalTypeDelist:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+// Msg는 액션별 트랜잭션 페이로드가 구현하는 인터페이스입니다.
+// ValidateBasic은 체인 상태 없이 확인할 수 있는 검사만 수행합니다.
+type Msg interface {
+	Action() string
+	ValidateBasic() error
+}
+
+// TxMsg는 액션별 페이로드를 담는 판별 공용체입니다. 정확히 하나의 필드만 설정되어야 하며,
+// 설정된 필드의 Action()은 TxData.Action과 같아야 합니다.
+type TxMsg struct {
+	PlaceOrder    *PlaceOrderMsg    `json:"place_order,omitempty"`
+	CancelOrder   *CancelOrderMsg   `json:"cancel_order,omitempty"`
+	ReleaseEscrow *ReleaseEscrowMsg `json:"release_escrow,omitempty"`
+	Deposit       *DepositMsg       `json:"deposit,omitempty"`
+	Withdraw      *WithdrawMsg      `json:"withdraw,omitempty"`
+	BindKey       *BindKeyMsg       `json:"bind_key,omitempty"`
+	CreateAccount *CreateAccountMsg `json:"create_account,omitempty"`
+
+	ProposeAmendment *ProposeAmendmentMsg `json:"propose_amendment,omitempty"`
+	ProposeDelisting *ProposeDelistingMsg `json:"propose_delisting,omitempty"`
+	WithdrawProposal *WithdrawProposalMsg `json:"withdraw_proposal,omitempty"`
+}
+
+// Unpack은 설정된 단 하나의 메시지를 반환합니다.
+func (m *TxMsg) Unpack() (Msg, error) {
+	var msgs []Msg
+	if m.PlaceOrder != nil {
+		msgs = append(msgs, m.PlaceOrder)
+	}
+	if m.CancelOrder != nil {
+		msgs = append(msgs, m.CancelOrder)
+	}
+	if m.ReleaseEscrow != nil {
+		msgs = append(msgs, m.ReleaseEscrow)
+	}
+	if m.Deposit != nil {
+		msgs = append(msgs, m.Deposit)
+	}
+	if m.Withdraw != nil {
+		msgs = append(msgs, m.Withdraw)
+	}
+	if m.BindKey != nil {
+		msgs = append(msgs, m.BindKey)
+	}
+	if m.CreateAccount != nil {
+		msgs = append(msgs, m.CreateAccount)
+	}
+	if m.ProposeAmendment != nil {
+		msgs = append(msgs, m.ProposeAmendment)
+	}
+	if m.ProposeDelisting != nil {
+		msgs = append(msgs, m.ProposeDelisting)
+	}
+	if m.WithdrawProposal != nil {
+		msgs = append(msgs, m.WithdrawProposal)
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("exactly one message must be set, got %d", len(msgs))
+	}
+	return msgs[0], nil
+}
+
+// msgActions는 타입이 있는 메시지를 반드시 사용해야 하는 액션 목록입니다.
+var msgActions = map[string]bool{
+	"place_order":         true,
+	"cancel_order":        true,
+	"release_escrow":      true,
+	"deposit_stablecoin":  true,
+	"withdraw_stablecoin": true,
+	"bind_account_key":    true,
+	"create_profile":      true,
+	"propose_amendment":   true,
+	"propose_delisting":   true,
+	"withdraw_proposal":   true,
+}
+
+// ValidateBasic은 액션과 메시지가 짝이 맞는지 확인하고 메시지 자체의 검사를 수행합니다.
+func (tx *TxData) ValidateBasic() error {
+	if !msgActions[tx.Action] {
+		if tx.Msg != nil {
+			return fmt.Errorf("action %s does not take a message", tx.Action)
+		}
+		return nil
+	}
+	if tx.Msg == nil {
+		return fmt.Errorf("action %s requires a message", tx.Action)
+	}
+	msg, err := tx.Msg.Unpack()
+	if err != nil {
+		return err
+	}
+	if msg.Action() != tx.Action {
+		return fmt.Errorf("message for %s does not match action %s", msg.Action(), tx.Action)
+	}
+	return msg.ValidateBasic()
+}
+
+// PlaceOrderMsg는 주문 접수 요청입니다.
+// 주문 ID와 접수 시간은 블록 실행 시 체인에서 정합니다.
+// 시장가 주문은 가격을 비워 두고, 체결 가격의 한계는 접수 시점의 최우선 호가와 MaxSlippageBps로 정해집니다.
+type PlaceOrderMsg struct {
+	PoliticianID   PoliticianID `json:"politician_id"`
+	OrderType      string       `json:"order_type"` // "buy" 또는 "sell"
+	Currency       string       `json:"currency"`   // "USDT" 또는 "USDC"
+	Quantity       int64        `json:"quantity"`
+	Price          int64        `json:"price"`                      // 지정가 주문의 가격. 시장가 주문은 0
+	Kind           string       `json:"kind,omitempty"`             // "limit"(기본) 또는 "market"
+	TimeInForce    string       `json:"time_in_force,omitempty"`    // "GTC", "IOC", "FOK". 기본은 지정가 GTC, 시장가 IOC
+	PostOnly       bool         `json:"post_only,omitempty"`        // 오더북과 바로 체결될 가격이면 접수하지 않음 (지정가 GTC만)
+	MaxSlippageBps int64        `json:"max_slippage_bps,omitempty"` // 시장가 주문의 최우선 호가 대비 허용 슬리피지 (베이시스 포인트)
+	ExpiresHeight  int64        `json:"expires_height,omitempty"`   // 이 블록 높이까지 유효 (GTC만, 0이면 만료 없음)
+	ExpiresAt      int64        `json:"expires_at,omitempty"`       // 이 시각(Unix 초)까지 유효 (GTC만, 0이면 만료 없음)
+}
+
+func (m *PlaceOrderMsg) Action() string { return "place_order" }
+
+func (m *PlaceOrderMsg) ValidateBasic() error {
+	if m.PoliticianID == "" {
+		return errors.New("politician_id is required")
+	}
+	if m.OrderType != "buy" && m.OrderType != "sell" {
+		return errors.New("order_type must be buy or sell")
+	}
+	if err := validateCurrency(m.Currency); err != nil {
+		return err
+	}
+	if err := validateOrderOptions(m.Kind, m.TimeInForce, m.PostOnly, m.Price, m.MaxSlippageBps); err != nil {
+		return err
+	}
+	if err := validateOrderExpiry(m.Kind, m.TimeInForce, m.ExpiresHeight, m.ExpiresAt); err != nil {
+		return err
+	}
+	if m.Kind == OrderKindMarket {
+		if m.Quantity <= 0 {
+			return errors.New("quantity must be positive")
+		}
+		return nil
+	}
+	if m.Quantity <= 0 || m.Price <= 0 || m.Quantity > math.MaxInt64/m.Price {
+		return errors.New("quantity and price must be positive and their product must not overflow")
+	}
+	return nil
+}
+
+// CancelOrderMsg는 미체결 주문 취소 요청입니다.
+type CancelOrderMsg struct {
+	OrderID string `json:"order_id"`
+}
+
+func (m *CancelOrderMsg) Action() string { return "cancel_order" }
+
+func (m *CancelOrderMsg) ValidateBasic() error {
+	if m.OrderID == "" {
+		return errors.New("order_id is required")
+	}
+	return nil
+}
+
+// ReleaseEscrowMsg는 종료된 주문에 남은 에스크로 해제 요청입니다.
+type ReleaseEscrowMsg struct {
+	OrderID string `json:"order_id"`
+}
+
+func (m *ReleaseEscrowMsg) Action() string { return "release_escrow" }
+
+func (m *ReleaseEscrowMsg) ValidateBasic() error {
+	if m.OrderID == "" {
+		return errors.New("order_id is required")
+	}
+	return nil
+}
+
+// DepositMsg는 외부 체인에서 확인된 스테이블코인 입금 기록입니다.
+// 입금을 확인하는 쪽은 사용자가 아니므로 AuthParams.KeyRegistrar 키가 서명해야 합니다.
+type DepositMsg struct {
+	Token       string `json:"token"` // "USDT" 또는 "USDC"
+	Amount      int64  `json:"amount"`
+	TxHash      string `json:"tx_hash"`
+	FromAddress string `json:"from_address"`
+}
+
+func (m *DepositMsg) Action() string { return "deposit_stablecoin" }
+
+func (m *DepositMsg) ValidateBasic() error {
+	if err := validateCurrency(m.Token); err != nil {
+		return err
+	}
+	if m.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if m.TxHash == "" || m.FromAddress == "" {
+		return errors.New("tx_hash and from_address are required")
+	}
+	return nil
+}
+
+// WithdrawMsg는 스테이블코인 출금 요청입니다.
+type WithdrawMsg struct {
+	Token     string `json:"token"` // "USDT" 또는 "USDC"
+	Amount    int64  `json:"amount"`
+	ToAddress string `json:"to_address"`
+}
+
+func (m *WithdrawMsg) Action() string { return "withdraw_stablecoin" }
+
+func (m *WithdrawMsg) ValidateBasic() error {
+	if err := validateCurrency(m.Token); err != nil {
+		return err
+	}
+	if m.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if m.ToAddress == "" {
+		return errors.New("to_address is required")
+	}
+	return nil
+}
+
+// BindKeyMsg는 공개키가 등록되기 전에 만들어진 계정에 서명 키를 등록하는 요청입니다.
+// 계정 소유자가 아니라 AuthParams.KeyRegistrar 키가 서명해야 합니다.
+type BindKeyMsg struct {
+	PubKey []byte `json:"pub_key"` // 계정에 등록할 ed25519 공개키 (32바이트)
+}
+
+func (m *BindKeyMsg) Action() string { return "bind_account_key" }
+
+func (m *BindKeyMsg) ValidateBasic() error {
+	if len(m.PubKey) != ed25519.PubKeySize {
+		return fmt.Errorf("pub_key must be %d bytes", ed25519.PubKeySize)
+	}
+	return nil
+}
+
+// CreateAccountMsg는 새 계정에 등록할 서명 키입니다. 계정 생성(create_profile)은
+// 새 사용자가 아니라 AuthParams.KeyRegistrar 키가 서명하므로, 사용자 키는 서명자가 아니라 이 메시지에서 옵니다.
+type CreateAccountMsg struct {
+	PubKey []byte `json:"pub_key"` // 새 계정에 등록할 ed25519 공개키 (32바이트)
+}
+
+func (m *CreateAccountMsg) Action() string { return "create_profile" }
+
+func (m *CreateAccountMsg) ValidateBasic() error {
+	if len(m.PubKey) != ed25519.PubKeySize {
+		return fmt.Errorf("pub_key must be %d bytes", ed25519.PubKeySize)
+	}
+	return nil
+}
+
+// ProposeAmendmentMsg는 정치인의 지역, 정당, 소개 URL을 바꾸는 제안입니다. 이름과 ID는 바꿀 수 없습니다.
+// 세 항목이 그대로 새 정보가 되므로 바꾸지 않을 항목도 현재 값으로 보내야 합니다.
+type ProposeAmendmentMsg struct {
+	PoliticianID PoliticianID `json:"politician_id"`
+	Region       string       `json:"region"`
+	Party        string       `json:"party"`
+	IntroUrl     string       `json:"intro_url"`
+}
+
+func (m *ProposeAmendmentMsg) Action() string { return "propose_amendment" }
+
+func (m *ProposeAmendmentMsg) ValidateBasic() error {
+	if m.PoliticianID == "" {
+		return errors.New("politician_id is required")
+	}
+	return nil
+}
+
+// ProposeDelistingMsg는 정치인을 상장 폐지하는 제안입니다.
+type ProposeDelistingMsg struct {
+	PoliticianID PoliticianID `json:"politician_id"`
+}
+
+func (m *ProposeDelistingMsg) Action() string { return "propose_delisting" }
+
+func (m *ProposeDelistingMsg) ValidateBasic() error {
+	if m.PoliticianID == "" {
+		return errors.New("politician_id is required")
+	}
+	return nil
+}
+
+// WithdrawProposalMsg는 제안자가 투표 중인 자신의 제안을 철회하는 요청입니다.
+type WithdrawProposalMsg struct {
+	ProposalID string `json:"proposal_id"`
+}
+
+func (m *WithdrawProposalMsg) Action() string { return "withdraw_proposal" }
+
+func (m *WithdrawProposalMsg) ValidateBasic() error {
+	if m.ProposalID == "" {
+		return errors.New("proposal_id is required")
+	}
+	return nil
+}
+
+func validateCurrency(currency string) error {
+	if currency != "USDT" && currency != "USDC" {
+		return fmt.Errorf("unsupported currency %q", currency)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 주문 종류입니다. 비어 있으면 지정가 주문입니다.
+const (
+	OrderKindLimit  = "limit"  // 지정한 가격 이하(매수) 또는 이상(매도)으로만 체결
+	OrderKindMarket = "market" // 접수 시점의 최우선 호가에서 허용 슬리피지 안의 가격으로 체결
+)
+
+// 주문 유효 기간(time in force)입니다. 비어 있으면 지정가는 GTC, 시장가는 IOC입니다.
+const (
+	TimeInForceGTC = "GTC" // 취소할 때까지 오더북에 남음
+	TimeInForceIOC = "IOC" // 즉시 체결되는 만큼만 체결하고 나머지는 취소
+	TimeInForceFOK = "FOK" // 전량 즉시 체결될 때만 체결하고, 아니면 아무것도 체결하지 않음
+)
+
+// MaxSlippageBps는 시장가 주문이 허용할 수 있는 최대 슬리피지입니다 (베이시스 포인트, 10000 = 100%).
+const MaxSlippageBps int64 = 10_000
+
+// validateOrderOptions는 주문 종류, 유효 기간, post-only, 슬리피지 조합이 올바른지 확인합니다.
+func validateOrderOptions(kind, timeInForce string, postOnly bool, price, slippageBps int64) error {
+	switch timeInForce {
+	case "", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK:
+	default:
+		return fmt.Errorf("unknown time_in_force %q (expected %s, %s or %s)", timeInForce, TimeInForceGTC, TimeInForceIOC, TimeInForceFOK)
+	}
+	switch kind {
+	case "", OrderKindLimit:
+		if slippageBps != 0 {
+			return errors.New("max_slippage_bps is only allowed for market orders")
+		}
+		if postOnly && timeInForce != "" && timeInForce != TimeInForceGTC {
+			return errors.New("post_only orders must be GTC")
+		}
+	case OrderKindMarket:
+		if price != 0 {
+			return errors.New("market orders must not have a price")
+		}
+		if slippageBps < 0 || slippageBps > MaxSlippageBps {
+			return fmt.Errorf("max_slippage_bps must be between 0 and %d", MaxSlippageBps)
+		}
+		if timeInForce == TimeInForceGTC {
+			return errors.New("market orders must be IOC or FOK")
+		}
+		if postOnly {
+			return errors.New("market orders cannot be post_only")
+		}
+	default:
+		return fmt.Errorf("unknown order kind %q (expected %s or %s)", kind, OrderKindLimit, OrderKindMarket)
+	}
+	return nil
+}
+
+// validateOrderExpiry는 만료 높이와 시각이 음수가 아니고, 오더북에 남는 주문(지정가 GTC)에만 지정되었는지 확인합니다.
+func validateOrderExpiry(kind, timeInForce string, expiresHeight, expiresAt int64) error {
+	if expiresHeight < 0 || expiresAt < 0 {
+		return errors.New("expires_height and expires_at must not be negative")
+	}
+	if expiresHeight == 0 && expiresAt == 0 {
+		return nil
+	}
+	if _, timeInForce = NormalizeOrderOptions(kind, timeInForce); timeInForce != TimeInForceGTC {
+		return errors.New("only GTC orders can have an expiry")
+	}
+	return nil
+}
+
+// NormalizeOrderOptions는 비어 있는 주문 종류와 유효 기간을 기본값으로 채웁니다.
+func NormalizeOrderOptions(kind, timeInForce string) (string, string) {
+	if kind == "" {
+		kind = OrderKindLimit
+	}
+	if timeInForce == "" {
+		timeInForce = TimeInForceGTC
+		if kind == OrderKindMarket {
+			timeInForce = TimeInForceIOC
+		}
+	}
+	return kind, timeInForce
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// 정치인 정보의 최대 길이입니다 (글자 수).
+const (
+	MaxPoliticianNameLength     = 50
+	MaxPoliticianRegionLength   = 100
+	MaxPoliticianPartyLength    = 50
+	MaxPoliticianIntroURLLength = 500
+)
+
+// ValidatePoliticianFields는 제안하거나 로스터에 넣는 정치인 정보의 형식을 확인합니다.
+// 이름은 필수이고, 각 항목은 최대 길이를 넘을 수 없으며, 소개 URL은 비어 있거나 http(s) 절대 URL이어야 합니다.
+func ValidatePoliticianFields(name, region, party, introURL string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, field := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"name", name, MaxPoliticianNameLength},
+		{"region", region, MaxPoliticianRegionLength},
+		{"party", party, MaxPoliticianPartyLength},
+		{"intro_url", introURL, MaxPoliticianIntroURLLength},
+	} {
+		if n := utf8.RuneCountInString(strings.TrimSpace(field.value)); n > field.max {
+			return fmt.Errorf("%s is too long (%d characters, max %d)", field.name, n, field.max)
+		}
+	}
+	if introURL = strings.TrimSpace(introURL); introURL != "" {
+		u, err := url.ParseRequestURI(introURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("intro_url must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// PoliticianDedupKey는 중복 제안을 찾기 위해 이름, 지역, 정당을 정규화한 키입니다.
+// 앞뒤 공백을 지우고, 연속된 공백을 하나로 줄이고, 영문 대소문자를 구분하지 않습니다.
+func PoliticianDedupKey(name, region, party string) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), " "))
+	}
+	return normalize(name) + "\x00" + normalize(region) + "\x00" + normalize(party)
+}
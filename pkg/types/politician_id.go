@@ -0,0 +1,37 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PoliticianID는 정치인의 정식 식별자입니다.
+// 상태 키, 계정의 코인 잔액 맵, 주문과 거래는 모두 이 ID로 정치인을 가리킵니다.
+type PoliticianID string
+
+const politicianIDPrefix = "politician_"
+
+// NewPoliticianID는 이름과 지역으로 정치인 ID를 만듭니다.
+// 같은 이름과 지역은 항상 같은 ID가 되고, 이름이 같아도 지역이 다르면 다른 ID가 됩니다.
+func NewPoliticianID(name, region string) PoliticianID {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(name) + "\x00" + strings.TrimSpace(region)))
+	return PoliticianID(fmt.Sprintf("%s%X", politicianIDPrefix, sum[:8]))
+}
+
+// Validate는 ID가 NewPoliticianID가 만드는 형식인지 확인합니다.
+func (id PoliticianID) Validate() error {
+	hash, ok := strings.CutPrefix(string(id), politicianIDPrefix)
+	if !ok || len(hash) != 16 || strings.ToUpper(hash) != hash {
+		return fmt.Errorf("invalid politician id %q", id)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return fmt.Errorf("invalid politician id %q", id)
+	}
+	return nil
+}
+
+func (id PoliticianID) String() string {
+	return string(id)
+}
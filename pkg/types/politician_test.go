@@ -0,0 +1,73 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePoliticianFields(t *testing.T) {
+	tests := []struct {
+		name                           string
+		pName, region, party, introURL string
+		wantErr                        string
+	}{
+		{name: "all fields", pName: "홍길동", region: "서울", party: "무소속", introURL: "https://example.com/hong"},
+		{name: "only a name", pName: "홍길동"},
+		{name: "http URL", pName: "홍길동", introURL: "http://example.com"},
+		{name: "URL with surrounding spaces", pName: "홍길동", introURL: "  https://example.com  "},
+		{name: "empty name", wantErr: "name is required"},
+		{name: "blank name", pName: " \t ", wantErr: "name is required"},
+		// 길이는 바이트가 아니라 글자 수로 셉니다. 한글 50자는 150바이트지만 허용됩니다.
+		{name: "name at the limit", pName: strings.Repeat("가", MaxPoliticianNameLength)},
+		{name: "name over the limit", pName: strings.Repeat("가", MaxPoliticianNameLength+1), wantErr: "name is too long"},
+		{name: "surrounding spaces are not counted", pName: " " + strings.Repeat("가", MaxPoliticianNameLength) + " "},
+		{name: "region over the limit", pName: "홍길동", region: strings.Repeat("a", MaxPoliticianRegionLength+1), wantErr: "region is too long"},
+		{name: "party over the limit", pName: "홍길동", party: strings.Repeat("a", MaxPoliticianPartyLength+1), wantErr: "party is too long"},
+		{name: "URL over the limit", pName: "홍길동", introURL: "https://example.com/" + strings.Repeat("a", MaxPoliticianIntroURLLength), wantErr: "intro_url is too long"},
+		{name: "relative URL", pName: "홍길동", introURL: "/about", wantErr: "intro_url must be"},
+		{name: "URL without a scheme", pName: "홍길동", introURL: "example.com", wantErr: "intro_url must be"},
+		{name: "javascript URL", pName: "홍길동", introURL: "javascript:alert(1)", wantErr: "intro_url must be"},
+		{name: "ftp URL", pName: "홍길동", introURL: "ftp://example.com", wantErr: "intro_url must be"},
+		{name: "URL without a host", pName: "홍길동", introURL: "https:///path", wantErr: "intro_url must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePoliticianFields(tt.pName, tt.region, tt.party, tt.introURL)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidatePoliticianFields() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidatePoliticianFields() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPoliticianDedupKey(t *testing.T) {
+	base := PoliticianDedupKey("Kim Minsu", "Seoul", "Independent")
+	tests := []struct {
+		name                 string
+		pName, region, party string
+		same                 bool
+	}{
+		{name: "identical", pName: "Kim Minsu", region: "Seoul", party: "Independent", same: true},
+		{name: "surrounding spaces", pName: "  Kim Minsu ", region: "Seoul\t", party: " Independent", same: true},
+		{name: "repeated inner spaces", pName: "Kim   Minsu", region: "Seoul", party: "Independent", same: true},
+		{name: "letter case", pName: "KIM MINSU", region: "seoul", party: "INDEPENDENT", same: true},
+		{name: "inner space removed", pName: "KimMinsu", region: "Seoul", party: "Independent"},
+		{name: "other region", pName: "Kim Minsu", region: "Busan", party: "Independent"},
+		{name: "other party", pName: "Kim Minsu", region: "Seoul", party: "Green"},
+		// 필드 경계가 구분자로 나뉘므로 필드 사이에서 글자를 옮겨도 같은 키가 되지 않습니다.
+		{name: "text moved across fields", pName: "Kim Minsu Seoul", region: "", party: "Independent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PoliticianDedupKey(tt.pName, tt.region, tt.party) == base; got != tt.same {
+				t.Fatalf("same key = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
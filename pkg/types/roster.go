@@ -0,0 +1,151 @@
+package types
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultPoliticianCoinSupply는 로스터에 발행량이 없을 때 정치인마다 발행하는 코인 수량입니다 (1,000만개).
+const DefaultPoliticianCoinSupply int64 = 10_000_000
+
+// rosterColumns는 CSV 로스터의 열 이름입니다. name 외의 열은 생략할 수 있습니다.
+var rosterColumns = map[string]bool{"id": true, "name": true, "region": true, "party": true, "intro_url": true, "total_coin_supply": true}
+
+// LoadPoliticianRoster는 CSV 또는 JSON 로스터 파일을 읽어 제네시스에 넣을 정치인 맵을 만듭니다.
+// 형식은 확장자(.csv, .json)로 구분합니다. 정치인은 NewPoliticianID로 만든 ID를 키로 사용합니다.
+//
+// CSV는 첫 줄이 열 이름입니다. id 열이 비어 있으면 이름과 지역으로 ID를 만듭니다.
+//
+//	name,region,party,intro_url,total_coin_supply
+//	홍길동,서울 종로구,무소속,,10000000
+//
+// JSON은 Politician 객체의 배열입니다.
+//
+//	[{"name": "홍길동", "region": "서울 종로구", "party": "무소속"}]
+func LoadPoliticianRoster(path string) (map[string]*Politician, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+	var politicians []*Politician
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		politicians, err = ParseRosterCSV(data)
+	case ".json":
+		politicians, err = ParseRosterJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported roster format %q (expected .csv or .json)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	roster, err := NewPoliticianRoster(politicians)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return roster, nil
+}
+
+// ParseRosterCSV는 CSV 로스터를 읽습니다.
+func ParseRosterCSV(data []byte) ([]*Politician, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !rosterColumns[name] {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("missing name column")
+	}
+
+	var politicians []*Politician
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return politicians, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		politician := &Politician{
+			ID:       PoliticianID(field("id")),
+			Name:     field("name"),
+			Region:   field("region"),
+			Party:    field("party"),
+			IntroUrl: field("intro_url"),
+		}
+		if supply := field("total_coin_supply"); supply != "" {
+			if politician.TotalCoinSupply, err = strconv.ParseInt(supply, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid total_coin_supply %q", line, supply)
+			}
+		}
+		politicians = append(politicians, politician)
+	}
+}
+
+// ParseRosterJSON은 JSON 로스터를 읽습니다.
+func ParseRosterJSON(data []byte) ([]*Politician, error) {
+	var politicians []*Politician
+	if err := json.Unmarshal(data, &politicians); err != nil {
+		return nil, fmt.Errorf("failed to parse roster: %w", err)
+	}
+	return politicians, nil
+}
+
+// NewPoliticianRoster는 로스터 항목을 ID를 키로 하는 맵으로 만들고, 비어 있는 ID와 아직 배포되지 않은 코인 수량을 채웁니다.
+// 정치인 정보가 ValidatePoliticianFields를 통과하지 못하거나 ID가 중복되면(같은 이름과 지역이 두 번 나오면) 오류를 반환합니다.
+func NewPoliticianRoster(politicians []*Politician) (map[string]*Politician, error) {
+	roster := make(map[string]*Politician, len(politicians))
+	for i, politician := range politicians {
+		if politician == nil {
+			return nil, fmt.Errorf("entry %d: name is required", i+1)
+		}
+		if err := ValidatePoliticianFields(politician.Name, politician.Region, politician.Party, politician.IntroUrl); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if politician.ID == "" {
+			politician.ID = NewPoliticianID(politician.Name, politician.Region)
+		}
+		if err := politician.ID.Validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if _, exists := roster[string(politician.ID)]; exists {
+			return nil, fmt.Errorf("entry %d: duplicate politician %q (%s, %s)", i+1, politician.ID, politician.Name, politician.Region)
+		}
+		if politician.TotalCoinSupply == 0 {
+			politician.TotalCoinSupply = DefaultPoliticianCoinSupply
+		}
+		if politician.TotalCoinSupply < 0 {
+			return nil, fmt.Errorf("entry %d: total_coin_supply must not be negative", i+1)
+		}
+		if politician.RemainingCoins == 0 && politician.DistributedCoins == 0 {
+			politician.RemainingCoins = politician.TotalCoinSupply
+		}
+		if politician.Supporters == nil {
+			politician.Supporters = []string{}
+		}
+		roster[string(politician.ID)] = politician
+	}
+	return roster, nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// CSV와 JSON으로 같은 정치인을 적은 로스터는 같은 맵이 됩니다.
+func TestRosterFormatsAgree(t *testing.T) {
+	csv := "\ufeffName, Region, party, intro_url, total_coin_supply\n" +
+		"홍길동, 서울 종로구, 무소속, https://example.com/hong, 500\n" +
+		"김민수,부산,,,\n" +
+		"김민수,서울,,,\n"
+	json := `[
+		{"name": "홍길동", "region": "서울 종로구", "party": "무소속", "intro_url": "https://example.com/hong", "total_coin_supply": 500},
+		{"name": "김민수", "region": "부산"},
+		{"name": "김민수", "region": "서울"}
+	]`
+	fromCSV, err := LoadPoliticianRoster(writeRoster(t, "roster.csv", csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := LoadPoliticianRoster(writeRoster(t, "roster.JSON", json))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromCSV, fromJSON) {
+		t.Fatalf("CSV roster %v differs from JSON roster %v", fromCSV, fromJSON)
+	}
+
+	if len(fromCSV) != 3 {
+		t.Fatalf("%d politicians, want 3 (same name in two regions)", len(fromCSV))
+	}
+	hong := fromCSV[string(NewPoliticianID("홍길동", "서울 종로구"))]
+	if hong == nil || hong.TotalCoinSupply != 500 || hong.RemainingCoins != 500 || hong.Party != "무소속" {
+		t.Fatalf("홍길동 = %+v", hong)
+	}
+	if kim := fromCSV[string(NewPoliticianID("김민수", "부산"))]; kim == nil || kim.TotalCoinSupply != DefaultPoliticianCoinSupply || kim.RemainingCoins != DefaultPoliticianCoinSupply {
+		t.Fatalf("김민수 without a supply = %+v, want the default supply", kim)
+	}
+}
+
+func TestRosterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr string
+	}{
+		{name: "unknown column", file: "r.csv", data: "name,region,twitter\n홍길동,서울,@hong\n", wantErr: `unknown column "twitter"`},
+		{name: "missing name column", file: "r.csv", data: "region,party\n서울,무소속\n", wantErr: "missing name column"},
+		{name: "empty CSV", file: "r.csv", data: "", wantErr: "failed to read header"},
+		{name: "blank name in CSV", file: "r.csv", data: "name,region\n홍길동,서울\n  ,부산\n", wantErr: "entry 2: name is required"},
+		{name: "missing name in JSON", file: "r.json", data: `[{"region": "서울"}]`, wantErr: "entry 1: name is required"},
+		{name: "null entry in JSON", file: "r.json", data: `[null]`, wantErr: "entry 1: name is required"},
+		{name: "duplicate name and region", file: "r.csv", data: "name,region,party\n홍길동,서울,무소속\n홍길동,서울,녹색당\n", wantErr: "entry 2: duplicate politician"},
+		// 이름과 지역의 앞뒤 공백은 ID에 쓰이지 않으므로 JSON에서도 같은 정치인입니다.
+		{name: "duplicate with surrounding spaces", file: "r.json", data: `[{"name": "홍길동", "region": "서울"}, {"name": " 홍길동", "region": "서울 "}]`, wantErr: "entry 2: duplicate politician"},
+		{name: "negative supply in CSV", file: "r.csv", data: "name,total_coin_supply\n홍길동,-1\n", wantErr: "entry 1: total_coin_supply must not be negative"},
+		{name: "negative supply in JSON", file: "r.json", data: `[{"name": "홍길동", "total_coin_supply": -5}]`, wantErr: "entry 1: total_coin_supply must not be negative"},
+		{name: "non-numeric supply", file: "r.csv", data: "name,total_coin_supply\n홍길동,many\n", wantErr: `line 2: invalid total_coin_supply "many"`},
+		{name: "malformed ID", file: "r.csv", data: "id,name\npolitician_xyz,홍길동\n", wantErr: "invalid politician id"},
+		{name: "invalid intro URL", file: "r.csv", data: "name,intro_url\n홍길동,example.com\n", wantErr: "intro_url must be"},
+		{name: "malformed JSON", file: "r.json", data: `{"name": "홍길동"}`, wantErr: "failed to parse roster"},
+		{name: "unsupported extension", file: "r.txt", data: "name\n홍길동\n", wantErr: `unsupported roster format ".txt"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPoliticianRoster(writeRoster(t, tt.file, tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadPoliticianRoster() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func writeRoster(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+)
+
+// txSignDomain은 서명 대상 바이트 앞에 붙는 도메인 구분자입니다.
+// 다른 용도로 만든 서명이 트랜잭션 서명으로 재사용되지 않도록 합니다.
+const txSignDomain = "politisian/tx/v1"
+
+// SignedTx는 체인에 제출되는 서명된 트랜잭션 봉투입니다.
+// Tx에는 MarshalTxData로 인코딩한 TxData가 담기며, 서명은 체인 ID, Nonce와 Tx 바이트 전체에 대해 만들어집니다.
+// 체인 ID는 봉투에 담지 않고 검증하는 노드의 체인 ID를 사용하므로, 다른 체인에서 만든 서명은 통과하지 않습니다.
+// 체인에는 MarshalSignedTx로 인코딩한 바이트가 제출됩니다.
+type SignedTx struct {
+	Tx        []byte `json:"tx"`        // MarshalTxData로 인코딩한 TxData
+	PubKey    []byte `json:"pub_key"`   // ed25519 공개키 (32바이트)
+	Signature []byte `json:"signature"` // SignBytes에 대한 ed25519 서명
+	Nonce     uint64 `json:"nonce"`     // 계정별로 엄격하게 증가하는 값
+}
+
+// SignBytes는 chainID 체인에서의 서명 대상 바이트를 반환합니다:
+// 도메인 구분자 || len(chainID)(uvarint) || chainID || nonce(big endian) || tx.
+func (s *SignedTx) SignBytes(chainID string) []byte {
+	buf := make([]byte, 0, len(txSignDomain)+binary.MaxVarintLen64+len(chainID)+8+len(s.Tx))
+	buf = append(buf, txSignDomain...)
+	buf = binary.AppendUvarint(buf, uint64(len(chainID)))
+	buf = append(buf, chainID...)
+	buf = binary.BigEndian.AppendUint64(buf, s.Nonce)
+	buf = append(buf, s.Tx...)
+	return buf
+}
+
+// SignTx는 txData를 privKey로 chainID 체인의 nonce와 함께 서명한 봉투를 만듭니다.
+func SignTx(privKey crypto.PrivKey, chainID string, nonce uint64, txData *TxData) (*SignedTx, error) {
+	signed := &SignedTx{
+		Tx:     MarshalTxData(txData),
+		PubKey: privKey.PubKey().Bytes(),
+		Nonce:  nonce,
+	}
+	signature, err := privKey.Sign(signed.SignBytes(chainID))
+	if err != nil {
+		return nil, fmt.Errorf("transaction sign error: %v", err)
+	}
+	signed.Signature = signature
+	return signed, nil
+}
@@ -15,6 +15,8 @@ type TxData struct {
 	ProposalID     string   `json:"proposal_id,omitempty"`
 	Vote           bool     `json:"vote,omitempty"`
 	Referrer       string   `json:"referrer,omitempty"`    // 추천인 지갑 주소
+	TallyMode      TallyMode `json:"tally_mode,omitempty"` // propose_politician의 집계 방식 (없으면 one_account_one_vote)
+	Msg            *TxMsg   `json:"msg,omitempty"`         // 거래·입출금 액션의 타입이 있는 페이로드
 }
 
 // ProfileInfoResponse는 사용자 프로필 조회 시 반환되는 데이터 구조입니다.
@@ -62,10 +64,14 @@ type Account struct {
 	PolygonWalletAddress string           `json:"polygon_wallet_address"` // Polygon 입금용 지갑 주소
 	ActiveOrders      []TradeOrder        `json:"active_orders"`      // 활성 거래 주문들
 	EscrowAccount     EscrowAccount       `json:"escrow_account"`     // 에스크로 계정
+	PubKey            []byte              `json:"pub_key,omitempty"`  // 트랜잭션 서명 검증용 ed25519 공개키
+	Nonce             uint64              `json:"nonce"`              // 마지막으로 처리된 트랜잭션 nonce
+	CreatedHeight     int64               `json:"created_height,omitempty"` // 계정이 만들어진 블록 높이 (제네시스 계정은 0)
 }
 
 // Politician은 정치인의 정보를 나타냅니다.
 type Politician struct {
+	ID               PoliticianID `json:"id"` // 정식 식별자 (NewPoliticianID)
 	Name             string   `json:"name"`
 	Region           string   `json:"region"`
 	Party            string   `json:"party"`
@@ -74,16 +80,27 @@ type Politician struct {
 	TotalCoinSupply  int64    `json:"total_coin_supply"`   // 총 발행량 (1,000만개)
 	RemainingCoins   int64    `json:"remaining_coins"`     // 남은 코인 수량
 	DistributedCoins int64    `json:"distributed_coins"`   // 이미 배포된 코인 수량
+	Delisted         bool     `json:"delisted,omitempty"`        // 상장 폐지 여부 (기록을 위해 상태에 남음)
+	DelistedHeight   int64    `json:"delisted_height,omitempty"` // 상장 폐지된 블록 높이
 }
 
-// Proposal은 새로운 정치인을 등록하기 위한 제안을 나타냅니다.
+// Proposal은 정치인을 등록, 수정 또는 상장 폐지하기 위한 제안을 나타냅니다.
+// 수정과 상장 폐지 제안의 Politician에는 대상 정치인의 ID와 (수정이면) 바뀔 정보가 들어갑니다.
+// 닫힌 제안도 상태와 함께 기록으로 남습니다.
 type Proposal struct {
 	ID         string     `json:"id"`
+	Type       string     `json:"type"` // register, amend, delist
 	Politician Politician `json:"politician"`
 	Proposer   string     `json:"proposer"`
 	Votes      map[string]bool `json:"votes"`
 	YesVotes   int        `json:"yes_votes"`
 	NoVotes    int        `json:"no_votes"`
+	SubmitHeight    int64 `json:"submit_height"`     // 제안된 블록 높이
+	VotingEndHeight int64 `json:"voting_end_height"` // 이 높이의 블록이 끝날 때 집계
+	Deposit         int64 `json:"deposit"`           // 제안자가 맡긴 USDT 보증금
+	TallyMode       TallyMode `json:"tally_mode"`      // 집계 방식
+	Status          string `json:"status"`            // voting, passed, rejected, expired, withdrawn
+	ClosedHeight    int64  `json:"closed_height,omitempty"` // 집계되거나 철회된 블록 높이
 }
 
 // ProposePolitisianRequest는 정치인 발의 API 요청을 위한 구조체입니다.
@@ -92,6 +109,7 @@ type ProposePolitisianRequest struct {
 	Region   string `json:"region"`
 	Party    string `json:"party"`
 	IntroUrl string `json:"introUrl,omitempty"`
+	TallyMode TallyMode `json:"tallyMode,omitempty"`
 }
 
 // VoteRequest는 투표 API 요청을 위한 구조체입니다.
@@ -136,14 +154,25 @@ type LoginResponse struct {
 type TradeOrder struct {
 	ID            string    `json:"id"`             // 주문 ID
 	UserID        string    `json:"user_id"`        // 주문한 사용자 ID
-	PoliticianID  string    `json:"politician_id"`  // 거래할 정치인 ID
+	PoliticianID  PoliticianID `json:"politician_id"` // 거래할 정치인 ID
 	OrderType     string    `json:"order_type"`     // "buy" 또는 "sell"
 	Currency      string    `json:"currency"`       // "USDT" 또는 "USDC"
 	Quantity      int64     `json:"quantity"`       // 수량
-	Price         int64     `json:"price"`          // 가격 (스테이블코인 단위)
-	Status        string    `json:"status"`         // "active", "filled", "cancelled", "partial"
+	Price         int64     `json:"price"`          // 가격 (스테이블코인 단위). 시장가 주문은 슬리피지를 반영한 체결 한계 가격
+	Kind          string    `json:"kind"`           // "limit" 또는 "market"
+	TimeInForce   string    `json:"time_in_force"`  // "GTC", "IOC" 또는 "FOK"
+	PostOnly      bool      `json:"post_only"`      // 오더북에 maker로만 들어가는 주문
+	MaxSlippageBps int64    `json:"max_slippage_bps,omitempty"` // 시장가 주문의 허용 슬리피지 (베이시스 포인트)
+	ExpiresHeight  int64    `json:"expires_height,omitempty"`   // 이 블록 높이까지 유효 (0이면 만료 없음)
+	ExpiresAt      int64    `json:"expires_at,omitempty"`       // 블록 시간이 이 시각(Unix 초)을 넘기 전까지 유효 (0이면 만료 없음)
+	// Status는 "active", "partial", "filled", "cancelled", "killed", "expired" 중 하나입니다.
+	// IOC와 시장가 주문은 즉시 체결되지 않은 나머지가 취소되어 "cancelled"(일부 체결이면 filled_quantity > 0)가 되고,
+	// 전량 체결할 수 없는 FOK 주문은 아무것도 체결하지 않고 "killed"가 됩니다.
+	// 만료 높이나 시각에 이른 미체결 주문은 블록 끝에서 "expired"가 되고 에스크로가 해제됩니다.
+	Status        string    `json:"status"`
 	FilledQuantity int64    `json:"filled_quantity"` // 체결된 수량
 	EscrowAmount   int64    `json:"escrow_amount"`   // 에스크로 동결 금액
+	Sequence       int64    `json:"sequence"`        // 체인이 부여한 접수 순서 (시간 우선순위)
 	CreatedAt     int64     `json:"created_at"`     // 생성 시간
 	UpdatedAt     int64     `json:"updated_at"`     // 업데이트 시간
 }
@@ -164,7 +193,8 @@ type Trade struct {
 	SellOrderID  string `json:"sell_order_id"` // 매도 주문 ID
 	BuyerID      string `json:"buyer_id"`      // 구매자 ID
 	SellerID     string `json:"seller_id"`     // 판매자 ID
-	PoliticianID string `json:"politician_id"` // 정치인 ID
+	PoliticianID PoliticianID `json:"politician_id"` // 정치인 ID
+	Currency     string `json:"currency"`      // 결제 통화 ("USDT" 또는 "USDC")
 	Quantity     int64  `json:"quantity"`      // 거래 수량
 	Price        int64  `json:"price"`         // 거래 가격
 	TotalAmount  int64  `json:"total_amount"`  // 총 거래 금액 (수량 × 가격)
@@ -173,8 +203,10 @@ type Trade struct {
 }
 
 // OrderBook은 특정 정치인의 오더북을 나타냅니다.
+// 오더북은 정치인과 결제 통화(USDT, USDC)마다 따로 있습니다.
 type OrderBook struct {
 	PoliticianID  string       `json:"politician_id"`
+	Currency      string       `json:"currency"`      // 결제 통화
 	BuyOrders     []TradeOrder `json:"buy_orders"`    // 매수 주문들 (가격 높은 순)
 	SellOrders    []TradeOrder `json:"sell_orders"`   // 매도 주문들 (가격 낮은 순)
 	LastPrice     int64        `json:"last_price"`    // 최근 체결가
@@ -187,7 +219,13 @@ type TradeRequest struct {
 	OrderType     string `json:"order_type"`     // "buy" 또는 "sell"
 	Currency      string `json:"currency"`       // "USDT" 또는 "USDC"
 	Quantity      int64  `json:"quantity"`       // 수량
-	Price         int64  `json:"price"`          // 가격 (스테이블코인 단위)
+	Price         int64  `json:"price"`          // 가격 (스테이블코인 단위). 시장가 주문은 0
+	Kind          string `json:"kind"`           // "limit"(기본) 또는 "market"
+	TimeInForce   string `json:"time_in_force"`  // "GTC", "IOC", "FOK". 기본은 지정가 GTC, 시장가 IOC
+	PostOnly      bool   `json:"post_only"`      // 바로 체결되는 가격이면 거부 (지정가 GTC만)
+	MaxSlippageBps int64 `json:"max_slippage_bps"` // 시장가 주문의 허용 슬리피지 (베이시스 포인트)
+	ExpiresHeight int64  `json:"expires_height"` // 이 블록 높이까지 유효 (0이면 만료 없음, GTC만)
+	ExpiresAt     int64  `json:"expires_at"`     // 이 시각(Unix 초)까지 유효 (0이면 만료 없음, GTC만)
 	PIN           string `json:"pin"`            // 거래 승인용 PIN
 }
 
@@ -211,6 +249,7 @@ type WithdrawRequest struct {
 // PoliticianPrice는 정치인 코인의 가격 정보를 나타냅니다.
 type PoliticianPrice struct {
 	PoliticianID   string `json:"politician_id"`
+	Currency       string `json:"currency"`        // 가격의 결제 통화
 	Name           string `json:"name"`
 	CurrentPrice   int64  `json:"current_price"`   // 현재 가격
 	Change24h      int64  `json:"change_24h"`      // 24시간 변동가
@@ -219,12 +258,32 @@ type PoliticianPrice struct {
 	Rank           int    `json:"rank"`            // 가격 순위
 }
 
+// AppState는 애플리케이션의 전체 상태를 나타내는 구조체입니다.
+// 저장과 앱 해시 계산에는 MarshalAppState의 protobuf 인코딩을 사용합니다.
+type AppState struct {
+	Version        int                       `json:"version"`
+	Height         int64                     `json:"height"`
+	AppHash        []byte                    `json:"app_hash"`
+	Accounts       map[string]*Account       `json:"accounts"`
+	Proposals      map[string]*Proposal      `json:"proposals"`
+	Politicians    map[string]*Politician    `json:"politicians"`
+	Orders         map[string]*TradeOrder    `json:"orders"`
+	Trades         map[string]*Trade         `json:"trades"`
+	OrderSequence  int64                     `json:"order_sequence"`
+	GovParams      *GovParams                `json:"gov_params"`
+	AuthParams     *AuthParams               `json:"auth_params,omitempty"`
+}
+
 // GenesisState는 블록체인의 초기 상태를 정의합니다.
+// 모든 필드는 InitChain에서 Validate로 검증한 뒤 불러오며, export-genesis가 같은 형식으로 내보냅니다.
 type GenesisState struct {
 	Accounts       map[string]*Account       `json:"accounts"`
 	Politicians    map[string]*Politician    `json:"politicians"`
+	Proposals      map[string]*Proposal      `json:"proposals,omitempty"` // 진행 중인 제안과 닫힌 제안 기록
 	Users          map[string]*User          `json:"users"`          // 사용자 정보 추가
 	Orders         []TradeOrder              `json:"orders"`         // 거래 주문들
-	EscrowAccounts map[string]*EscrowAccount `json:"escrow_accounts"` // 에스크로 계정들
 	Trades         []Trade                   `json:"trades"`         // 체결된 거래 기록들
+	OrderSequence  int64                     `json:"order_sequence,omitempty"` // 마지막으로 부여한 주문 접수 순서
+	GovParams      *GovParams                `json:"gov_params,omitempty"`     // 거버넌스 파라미터 (없으면 DefaultGovParams)
+	AuthParams     *AuthParams               `json:"auth_params,omitempty"`    // 인증 파라미터 (없으면 계정 생성·입금·키 등록 불가)
 } 
\ No newline at end of file
@@ -296,7 +296,7 @@ func createBlockchainAccount(userID, email, walletAddress string, politicians []
 		Politicians:   politicians,
 	}
 
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		return fmt.Errorf("transaction marshal error: %v", err)
 	}
@@ -318,7 +318,7 @@ func sendInitialCoinsTransaction(userID string, politicians []string) error {
 		Politicians: politicians,
 	}
 
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		return fmt.Errorf("initial coins transaction marshal error: %v", err)
 	}
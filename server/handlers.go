@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -31,6 +32,26 @@ func broadcastAndCheckTx(ctx context.Context, txBytes []byte) error {
 	return nil
 }
 
+// broadcastAndCommitTx는 트랜잭션이 블록에 포함되어 실행될 때까지 기다린 뒤 실행 결과를 반환합니다.
+// 주문 ID처럼 블록 실행 시 체인이 정하는 값을 받아야 할 때 사용합니다.
+func broadcastAndCommitTx(ctx context.Context, txBytes []byte) (*types.ExecTxResult, error) {
+	res, err := blockchainClient.BroadcastTxCommit(ctx, txBytes)
+	if err != nil {
+		log.Printf("Error broadcasting tx: %v", err)
+		return nil, fmt.Errorf("RPC 오류: %v", err)
+	}
+	if res.CheckTx.Code != types.CodeTypeOK {
+		log.Printf("Tx failed. Code: %d, Log: %s", res.CheckTx.Code, res.CheckTx.Log)
+		return nil, fmt.Errorf("트랜잭션 실패: %s (코드: %d)", res.CheckTx.Log, res.CheckTx.Code)
+	}
+	if res.TxResult.Code != types.CodeTypeOK {
+		log.Printf("Tx execution failed. Code: %d, Log: %s", res.TxResult.Code, res.TxResult.Log)
+		return nil, fmt.Errorf("트랜잭션 실행 실패: %s (코드: %d)", res.TxResult.Log, res.TxResult.Code)
+	}
+	log.Printf("Tx committed. Hash: %s, Height: %d", res.Hash.String(), res.Height)
+	return &res.TxResult, nil
+}
+
 func handleUserProfile(w http.ResponseWriter, r *http.Request) {
 	log.Println("Attempting to handle /api/user/profile request")
 	userID, ok := r.Context().Value("userID").(string)
@@ -40,7 +61,7 @@ func handleUserProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// ABCI 쿼리를 통해 사용자 계정 정보 가져오기
-	queryPath := fmt.Sprintf("/account?address=%s", userID)
+	queryPath := fmt.Sprintf("/account?address=%s", url.QueryEscape(userID))
 	log.Printf("Querying ABCI for user profile: %s", queryPath)
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
@@ -133,7 +154,8 @@ func handleUserProfileFromSession(w http.ResponseWriter, r *http.Request, userID
 
 func handleGetPolitisians(w http.ResponseWriter, r *http.Request) {
 	log.Println("Attempting to handle /api/github.com/jclee286/politisian/list request")
-	res, err := blockchainClient.ABCIQuery(context.Background(), "/proposals/list", nil)
+	// status 파라미터(voting, passed, rejected, expired, withdrawn)와 limit, offset을 그대로 체인 쿼리에 넘깁니다.
+	res, err := blockchainClient.ABCIQuery(context.Background(), "/proposals/list?"+listQueryParams(r, "status").Encode(), nil)
 	if err != nil {
 		log.Printf("Error querying for proposals list: %v", err)
 		http.Error(w, fmt.Sprintf("블록체인 쿼리 실패: %v", err), http.StatusInternalServerError)
@@ -151,10 +173,21 @@ func handleGetPolitisians(w http.ResponseWriter, r *http.Request) {
 	w.Write(res.Response.Value)
 }
 
+// listQueryParams는 요청의 limit, offset과 keys로 지정한 파라미터 중 값이 있는 것만 체인 목록 쿼리용으로 옮깁니다.
+func listQueryParams(r *http.Request, keys ...string) url.Values {
+	params := url.Values{}
+	for _, key := range append([]string{"limit", "offset"}, keys...) {
+		if v := r.URL.Query().Get(key); v != "" {
+			params.Set(key, v)
+		}
+	}
+	return params
+}
+
 // handleGetRegisteredPoliticians는 등록된 정치인 목록을 조회합니다.
 func handleGetRegisteredPoliticians(w http.ResponseWriter, r *http.Request) {
 	log.Println("Attempting to handle /api/github.com/jclee286/politisian/registered request")
-	res, err := blockchainClient.ABCIQuery(context.Background(), "/github.com/jclee286/politisian/list", nil)
+	res, err := blockchainClient.ABCIQuery(context.Background(), "/github.com/jclee286/politisian/list?"+listQueryParams(r).Encode(), nil)
 	if err != nil {
 		log.Printf("Error querying for politicians list: %v", err)
 		http.Error(w, fmt.Sprintf("블록체인 쿼리 실패: %v", err), http.StatusInternalServerError)
@@ -207,7 +240,11 @@ func handleVoteOnProposal(w http.ResponseWriter, r *http.Request) {
 		ProposalID: proposalID,
 		Vote:       reqBody.Vote,
 	}
-	txBytes, _ := json.Marshal(txData)
+	txBytes, err := signTx(txData)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	if err := broadcastAndCheckTx(r.Context(), txBytes); err != nil {
 		log.Printf("Error broadcasting vote transaction: %v", err)
@@ -236,7 +273,7 @@ func handleProfileSave(w http.ResponseWriter, r *http.Request) {
 	log.Printf("User %s is saving profile - nickname: %s, politicians: %v", userID, reqBody.Nickname, reqBody.Politisians)
 
 	// 먼저 기존 계정이 있는지 확인
-	queryPath := fmt.Sprintf("/account?address=%s", userID)
+	queryPath := fmt.Sprintf("/account?address=%s", url.QueryEscape(userID))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	
 	var action string
@@ -264,7 +301,11 @@ func handleProfileSave(w http.ResponseWriter, r *http.Request) {
 		Politicians:   reqBody.Politisians,
 		Referrer:      reqBody.Referrer,
 	}
-	txBytes, _ := json.Marshal(txData)
+	txBytes, err := signTx(txData)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	if err := broadcastAndCheckTx(r.Context(), txBytes); err != nil {
 		log.Printf("Error broadcasting profile save transaction: %v", err)
@@ -283,10 +324,11 @@ func handleProposePolitician(w http.ResponseWriter, r *http.Request) {
 	log.Println("Attempting to handle /api/github.com/jclee286/politisian/propose request")
 	userID, _ := r.Context().Value("userID").(string)
 	var reqBody struct {
-		Name     string `json:"name"`
-		Region   string `json:"region"`
-		Party    string `json:"party"`
-		IntroUrl string `json:"introUrl"`
+		Name      string           `json:"name"`
+		Region    string           `json:"region"`
+		Party     string           `json:"party"`
+		IntroUrl  string           `json:"introUrl"`
+		TallyMode ptypes.TallyMode `json:"tallyMode"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
 		http.Error(w, "잘못된 요청", http.StatusBadRequest)
@@ -307,8 +349,13 @@ func handleProposePolitician(w http.ResponseWriter, r *http.Request) {
 		Region:         reqBody.Region,
 		Party:          reqBody.Party,
 		IntroUrl:       reqBody.IntroUrl,
+		TallyMode:      reqBody.TallyMode,
+	}
+	txBytes, err := signTx(txData)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	txBytes, _ := json.Marshal(txData)
 
 	if err := broadcastAndCheckTx(r.Context(), txBytes); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -335,7 +382,7 @@ func createBasicAccount(userID string, r *http.Request) error {
 	
 	// 기존 사용자 정보에서 정치인 목록 가져오기
 	var selectedPoliticians []string
-	userQueryPath := fmt.Sprintf("/user?id=%s", userID)
+	userQueryPath := fmt.Sprintf("/user?id=%s", url.QueryEscape(userID))
 	res, err := blockchainClient.ABCIQuery(context.Background(), userQueryPath, nil)
 	if err == nil && res.Response.Code == 0 {
 		var user ptypes.User
@@ -363,7 +410,7 @@ func createBasicAccount(userID string, r *http.Request) error {
 		Politicians:   selectedPoliticians, // 기존 사용자의 정치인 목록 또는 기본값
 	}
 	
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		log.Printf("Error marshaling basic account transaction: %v", err)
 		return err
@@ -411,7 +458,7 @@ func handleClaimInitialCoins(w http.ResponseWriter, r *http.Request) {
 
 	// 사용자 계정 조회
 	log.Printf("🔍 사용자 계정 조회 시작 - 사용자: %s", userID)
-	queryPath := fmt.Sprintf("/account?address=%s", userID)
+	queryPath := fmt.Sprintf("/account?address=%s", url.QueryEscape(userID))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
 		log.Printf("❌ ABCI 조회 오류 - 사용자: %s, 오류: %v", userID, err)
@@ -462,7 +509,7 @@ func handleClaimInitialCoins(w http.ResponseWriter, r *http.Request) {
 		Politicians: userPoliticians, // 사용자가 선택한 정치인들
 	}
 
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		log.Printf("Error marshaling claim transaction: %v", err)
 		http.Error(w, "트랜잭션 생성 실패", http.StatusInternalServerError)
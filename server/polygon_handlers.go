@@ -99,15 +99,14 @@ func handleStablecoinWithdraw(w http.ResponseWriter, r *http.Request) {
 		Action: "withdraw_stablecoin",
 		UserID: userID,
 		TxID:   fmt.Sprintf("withdraw_%s_%d", userID, time.Now().UnixNano()),
-		// 출금 정보를 Politicians 필드에 임시로 전달 (구조 개선 필요)
-		Politicians: []string{
-			fmt.Sprintf("amount:%d", req.Amount),
-			fmt.Sprintf("token_type:%s", req.TokenType),
-			fmt.Sprintf("to_address:%s", req.ToAddress),
-		},
+		Msg: &ptypes.TxMsg{Withdraw: &ptypes.WithdrawMsg{
+			Token:     req.TokenType,
+			Amount:    req.Amount,
+			ToAddress: req.ToAddress,
+		}},
 	}
 
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		http.Error(w, "출금 처리 중 오류가 발생했습니다", http.StatusInternalServerError)
 		return
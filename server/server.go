@@ -34,8 +34,10 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func StartServer(node *node.Node) {
+// StartServer는 node에 연결된 HTTP API 서버를 port에서 실행합니다.
+func StartServer(node *node.Node, port string) {
 	blockchainClient = local.New(node)
+	txChainID = node.GenesisDoc().ChainID
 
 	mux := http.NewServeMux()
 
@@ -81,11 +83,6 @@ func StartServer(node *node.Node) {
 	mux.HandleFunc("/", rootFileHandler(fs))
 
 	// 서버 시작
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
 	log.Printf("HTTP server listening on :%s", port)
 	err := http.ListenAndServe(":"+port, mux)
 	if err != nil {
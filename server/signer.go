@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	ptypes "github.com/jclee286/politisian/pkg/types"
+)
+
+// 사용자별로 마지막으로 사용한 nonce를 기억합니다.
+// 아직 블록에 포함되지 않은 트랜잭션이 있어도 같은 nonce를 다시 쓰지 않도록 합니다.
+var (
+	txNonceMu    sync.Mutex
+	txNonceCache = make(map[string]uint64)
+)
+
+// txSigningSecret은 사용자 서명키를 파생하는 서버 비밀값입니다. LoadTxSigningSecret으로 한 번 읽습니다.
+var txSigningSecret string
+
+// txChainID는 서버가 서명하는 트랜잭션의 체인 ID입니다. StartServer가 노드의 제네시스에서 정합니다.
+var txChainID string
+
+// LoadTxSigningSecret은 TX_SIGNING_SECRET 환경 변수에서 서명 비밀값을 읽습니다.
+// 이 비밀값에서 모든 사용자의 서명키와 등록 키가 파생되므로, 비밀값을 가진 서버(와 CLI)가 사실상 모든 사용자 키를 보관합니다.
+// 체인의 서명 검증은 서버가 아닌 사람이 사용자를 사칭하는 것을 막을 뿐, 서버 운영자로부터 사용자를 보호하지는 않습니다.
+// 비밀값을 아는 사람은 모든 사용자로 서명할 수 있으므로 기본값 없이, 비어 있으면 오류를 반환합니다.
+// 노드와 CLI는 서명하기 전에 반드시 호출해야 합니다.
+func LoadTxSigningSecret() error {
+	secret := os.Getenv("TX_SIGNING_SECRET")
+	if secret == "" {
+		return errors.New("TX_SIGNING_SECRET is not set; set it to a long random value shared by the node and the CLI")
+	}
+	txSigningSecret = secret
+	return nil
+}
+
+// UserSigningKey는 사용자 ID로부터 결정적으로 ed25519 서명키를 파생합니다.
+// 키는 사용자가 아니라 서버가 보관하며, 서버는 사용자의 요청을 받아 이 키로 대신 서명합니다(커스터디 방식).
+// 같은 TX_SIGNING_SECRET을 쓰면 CLI(politisian tx)도 서버와 같은 키로 서명합니다.
+func UserSigningKey(userID string) ed25519.PrivKey {
+	if txSigningSecret == "" {
+		panic("tx signing secret is not loaded; call LoadTxSigningSecret at startup")
+	}
+	return ed25519.GenPrivKeyFromSecret([]byte(txSigningSecret + ":" + userID))
+}
+
+// RegistrarSigningKey는 등록 키의 서명키입니다. 계정 생성, 입금 기록, 공개키가 없는 이전 계정의 키 등록에 서명합니다.
+// 사용자 키와 같은 비밀값에서 파생하지만 구분자가 달라 어떤 사용자 ID로도 같은 키가 나오지 않습니다.
+func RegistrarSigningKey() ed25519.PrivKey {
+	if txSigningSecret == "" {
+		panic("tx signing secret is not loaded; call LoadTxSigningSecret at startup")
+	}
+	return ed25519.GenPrivKeyFromSecret([]byte(txSigningSecret + "/key-registrar"))
+}
+
+// RegistrarPubKey는 제네시스의 auth_params.key_registrar에 넣을 등록 키의 공개키입니다.
+func RegistrarPubKey() []byte {
+	return RegistrarSigningKey().PubKey().Bytes()
+}
+
+// nextTxNonce는 체인에 기록된 nonce와 로컬 캐시 중 큰 값에 1을 더해 반환합니다.
+func nextTxNonce(userID string) uint64 {
+	txNonceMu.Lock()
+	defer txNonceMu.Unlock()
+
+	nonce := txNonceCache[userID]
+	if account, err := getUserAccount(userID); err == nil && account.Nonce > nonce {
+		nonce = account.Nonce
+	}
+	nonce++
+	txNonceCache[userID] = nonce
+	return nonce
+}
+
+// SigningKeyFor는 txData에 서명할 키를 반환합니다. 등록 키가 서명해야 하는 액션(ptypes.IsRegistrarAction)이면
+// 등록 키를, 아니면 사용자 키를 반환합니다. create_profile이면 새 계정에 등록할 사용자 키를 메시지로 채웁니다.
+func SigningKeyFor(txData *ptypes.TxData) ed25519.PrivKey {
+	if !ptypes.IsRegistrarAction(txData.Action) {
+		return UserSigningKey(txData.UserID)
+	}
+	if txData.Action == "create_profile" {
+		txData.Msg = &ptypes.TxMsg{CreateAccount: &ptypes.CreateAccountMsg{PubKey: UserSigningKey(txData.UserID).PubKey().Bytes()}}
+	}
+	return RegistrarSigningKey()
+}
+
+// signTx는 TxData를 서명하여 체인에 제출할 SignedTx 바이트(protobuf)를 만듭니다. 서명키는 SigningKeyFor로 정합니다.
+// 공개키가 등록되기 전에 만들어진 계정이면 먼저 사용자 키를 등록합니다.
+func signTx(txData ptypes.TxData) ([]byte, error) {
+	if !ptypes.IsRegistrarAction(txData.Action) {
+		if err := bindLegacyAccountKey(txData.UserID); err != nil {
+			return nil, err
+		}
+	}
+	signed, err := ptypes.SignTx(SigningKeyFor(&txData), txChainID, nextTxNonce(txData.UserID), &txData)
+	if err != nil {
+		return nil, err
+	}
+	return ptypes.MarshalSignedTx(signed), nil
+}
+
+// bindLegacyAccountKey는 체인 계정에 공개키가 없으면 등록 키로 서명한 bind_account_key를 제출해
+// 사용자 키를 등록하고, 블록에 포함될 때까지 기다립니다. 계정이 없거나 이미 키가 있으면 아무것도 하지 않습니다.
+func bindLegacyAccountKey(userID string) error {
+	account, err := getUserAccount(userID)
+	if err != nil || len(account.PubKey) > 0 {
+		return nil
+	}
+	randBytes := make([]byte, 4)
+	rand.Read(randBytes)
+	txData := ptypes.TxData{
+		TxID:   fmt.Sprintf("%s-bind-key-%d-%x", userID, time.Now().UnixNano(), randBytes),
+		Action: "bind_account_key",
+		UserID: userID,
+		Msg:    &ptypes.TxMsg{BindKey: &ptypes.BindKeyMsg{PubKey: UserSigningKey(userID).PubKey().Bytes()}},
+	}
+	signed, err := ptypes.SignTx(RegistrarSigningKey(), txChainID, nextTxNonce(userID), &txData)
+	if err != nil {
+		return err
+	}
+	if _, err := broadcastAndCommitTx(context.Background(), ptypes.MarshalSignedTx(signed)); err != nil {
+		return fmt.Errorf("계정 공개키 등록 실패: %w", err)
+	}
+	log.Printf("Bound signing key for legacy account %s", userID)
+	return nil
+}
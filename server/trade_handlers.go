@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -14,14 +15,20 @@ import (
 )
 
 // handleGetPoliticianPrices는 정치인 코인 가격 순위를 반환합니다.
+// 가격은 ?currency= 로 지정한 결제 통화(기본 USDT)의 오더북 기준입니다.
 func handleGetPoliticianPrices(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	currency, ok := quoteCurrencyParam(w, r)
+	if !ok {
+		return
+	}
+
 	// 모든 정치인의 가격 정보 수집
-	prices, err := getAllPoliticianPrices()
+	prices, err := getAllPoliticianPrices(currency)
 	if err != nil {
 		log.Printf("Error getting politician prices: %v", err)
 		http.Error(w, "가격 정보를 불러올 수 없습니다", http.StatusInternalServerError)
@@ -43,6 +50,7 @@ func handleGetPoliticianPrices(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleGetOrderBook은 특정 정치인의 오더북을 반환합니다.
+// 결제 통화마다 오더북이 따로 있으며, ?currency= 로 지정합니다 (기본 USDT).
 func handleGetOrderBook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -57,7 +65,12 @@ func handleGetOrderBook(w http.ResponseWriter, r *http.Request) {
 	}
 	politicianID := parts[3] // /api/orderbook/{politician_id}
 
-	orderBook, err := getOrderBookForPolitician(politicianID)
+	currency, ok := quoteCurrencyParam(w, r)
+	if !ok {
+		return
+	}
+
+	orderBook, err := getOrderBookForPolitician(politicianID, currency)
 	if err != nil {
 		log.Printf("Error getting orderbook for %s: %v", politicianID, err)
 		http.Error(w, "오더북을 불러올 수 없습니다", http.StatusInternalServerError)
@@ -87,8 +100,9 @@ func handlePlaceOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 입력 검증
-	if req.PoliticianID == "" || req.OrderType == "" || req.Quantity <= 0 || req.Price <= 0 {
+	// 입력 검증 (시장가 주문은 가격 없이 허용 슬리피지로 접수합니다)
+	isMarket := req.Kind == ptypes.OrderKindMarket
+	if req.PoliticianID == "" || req.OrderType == "" || req.Quantity <= 0 || (!isMarket && req.Price <= 0) {
 		http.Error(w, "모든 필드를 올바르게 입력해주세요", http.StatusBadRequest)
 		return
 	}
@@ -108,6 +122,15 @@ func handlePlaceOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 주문 종류, 유효 기간, post-only, 슬리피지 조합은 체인과 같은 규칙으로 미리 확인합니다.
+	if isMarket {
+		req.Price = 0
+	}
+	if err := placeOrderMsg(req).ValidateBasic(); err != nil {
+		http.Error(w, "주문 옵션이 올바르지 않습니다: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// PIN 검증
 	if err := verifyUserPIN(userID, req.PIN); err != nil {
 		http.Error(w, "PIN이 올바르지 않습니다", http.StatusUnauthorized)
@@ -115,7 +138,7 @@ func handlePlaceOrder(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 주문 처리
-	orderID, err := placeTradeOrder(userID, req)
+	orderID, status, err := placeTradeOrder(userID, req)
 	if err != nil {
 		log.Printf("Error placing order: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -126,6 +149,7 @@ func handlePlaceOrder(w http.ResponseWriter, r *http.Request) {
 		"success":  true,
 		"message":  "주문이 성공적으로 등록되었습니다",
 		"order_id": orderID,
+		"status":   status,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -168,7 +192,7 @@ func handleCancelOrder(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleGetUserOrders는 사용자의 활성 주문 목록을 반환합니다.
+// handleGetUserOrders는 사용자의 미체결 주문과 만료된 주문 목록을 반환합니다.
 func handleGetUserOrders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -181,7 +205,8 @@ func handleGetUserOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orders, err := getUserActiveOrders(userID)
+	// 기본으로는 미체결 주문과 만료된 주문을 보여 주고, ?status= 로 특정 상태만 조회할 수 있습니다.
+	orders, err := getUserOrders(userID, r.URL.Query().Get("status"))
 	if err != nil {
 		log.Printf("Error getting user orders: %v", err)
 		http.Error(w, "주문 목록을 불러올 수 없습니다", http.StatusInternalServerError)
@@ -194,29 +219,77 @@ func handleGetUserOrders(w http.ResponseWriter, r *http.Request) {
 
 // 헬퍼 함수들
 
-// getAllPoliticianPrices는 모든 정치인의 가격 정보를 수집합니다.
-func getAllPoliticianPrices() ([]ptypes.PoliticianPrice, error) {
-	// 등록된 정치인 목록 조회
-	queryPath := "/politicians"
-	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
-	if err != nil {
-		return nil, fmt.Errorf("politicians query error: %v", err)
+// placeOrderMsg는 거래 요청을 체인에 보낼 주문 접수 메시지로 바꿉니다.
+func placeOrderMsg(req ptypes.TradeRequest) *ptypes.PlaceOrderMsg {
+	return &ptypes.PlaceOrderMsg{
+		PoliticianID:   ptypes.PoliticianID(req.PoliticianID),
+		OrderType:      req.OrderType,
+		Currency:       req.Currency,
+		Quantity:       req.Quantity,
+		Price:          req.Price,
+		Kind:           req.Kind,
+		TimeInForce:    req.TimeInForce,
+		PostOnly:       req.PostOnly,
+		MaxSlippageBps: req.MaxSlippageBps,
+		ExpiresHeight:  req.ExpiresHeight,
+		ExpiresAt:      req.ExpiresAt,
 	}
+}
 
-	if res.Response.Code != 0 {
-		return nil, fmt.Errorf("politicians not found")
+// quoteCurrencyParam은 ?currency= 파라미터로 오더북의 결제 통화를 읽습니다. 없으면 USDT입니다.
+// 지원하지 않는 통화면 400 응답을 쓰고 false를 반환합니다.
+func quoteCurrencyParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	if currency == "" {
+		return "USDT", true
+	}
+	if currency != "USDT" && currency != "USDC" {
+		http.Error(w, "지원하지 않는 통화입니다 (USDT 또는 USDC)", http.StatusBadRequest)
+		return "", false
+	}
+	return currency, true
+}
+
+// politicianPageSize는 체인의 목록 쿼리가 한 번에 돌려주는 최대 개수입니다.
+const politicianPageSize = 1000
+
+// getAllPoliticians는 등록된 정치인 목록을 페이지 단위로 모두 조회합니다.
+func getAllPoliticians() (map[string]*ptypes.Politician, error) {
+	politicians := make(map[string]*ptypes.Politician)
+	for offset := 0; ; offset += politicianPageSize {
+		queryPath := fmt.Sprintf("/politicians?limit=%d&offset=%d", politicianPageSize, offset)
+		res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("politicians query error: %v", err)
+		}
+		if res.Response.Code != 0 {
+			return nil, fmt.Errorf("politicians not found")
+		}
+		var page map[string]*ptypes.Politician
+		if err := json.Unmarshal(res.Response.Value, &page); err != nil {
+			return nil, fmt.Errorf("politicians unmarshal error: %v", err)
+		}
+		for id, politician := range page {
+			politicians[id] = politician
+		}
+		if len(page) < politicianPageSize {
+			return politicians, nil
+		}
 	}
+}
 
-	var politicians map[string]*ptypes.Politician
-	if err := json.Unmarshal(res.Response.Value, &politicians); err != nil {
-		return nil, fmt.Errorf("politicians unmarshal error: %v", err)
+// getAllPoliticianPrices는 모든 정치인의 가격 정보를 해당 결제 통화의 오더북에서 수집합니다.
+func getAllPoliticianPrices(currency string) ([]ptypes.PoliticianPrice, error) {
+	politicians, err := getAllPoliticians()
+	if err != nil {
+		return nil, err
 	}
 
 	var prices []ptypes.PoliticianPrice
 
 	// 각 정치인의 가격 정보 계산
 	for id, politician := range politicians {
-		orderBook, err := getOrderBookForPolitician(id)
+		orderBook, err := getOrderBookForPolitician(id, currency)
 		if err != nil {
 			log.Printf("Error getting orderbook for %s: %v", id, err)
 			continue
@@ -231,6 +304,7 @@ func getAllPoliticianPrices() ([]ptypes.PoliticianPrice, error) {
 			CurrentPrice: currentPrice,
 			Change24h:    0, // TODO: 24시간 변동가 계산
 			Volume24h:    orderBook.Volume24h,
+			Currency:     currency,
 		}
 
 		prices = append(prices, price)
@@ -239,10 +313,10 @@ func getAllPoliticianPrices() ([]ptypes.PoliticianPrice, error) {
 	return prices, nil
 }
 
-// getOrderBookForPolitician은 특정 정치인의 오더북을 반환합니다.
-func getOrderBookForPolitician(politicianID string) (*ptypes.OrderBook, error) {
-	// 블록체인에서 해당 정치인의 모든 활성 주문 조회
-	queryPath := fmt.Sprintf("/orders?politician_id=%s", politicianID)
+// getOrderBookForPolitician은 특정 정치인의 결제 통화별 오더북을 반환합니다.
+func getOrderBookForPolitician(politicianID, currency string) (*ptypes.OrderBook, error) {
+	// 블록체인에서 해당 정치인의 해당 통화 주문 조회
+	queryPath := fmt.Sprintf("/orders?politician_id=%s&currency=%s", url.QueryEscape(politicianID), url.QueryEscape(currency))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("orders query error: %v", err)
@@ -259,7 +333,7 @@ func getOrderBookForPolitician(politicianID string) (*ptypes.OrderBook, error) {
 	var buyOrders, sellOrders []ptypes.TradeOrder
 
 	for _, order := range orders {
-		if order.Status == "active" {
+		if isOpenOrder(order) {
 			if order.OrderType == "buy" {
 				buyOrders = append(buyOrders, order)
 			} else if order.OrderType == "sell" {
@@ -280,10 +354,11 @@ func getOrderBookForPolitician(politicianID string) (*ptypes.OrderBook, error) {
 
 	orderBook := &ptypes.OrderBook{
 		PoliticianID: politicianID,
+		Currency:     currency,
 		BuyOrders:    buyOrders,
 		SellOrders:   sellOrders,
-		LastPrice:    calculateLastPrice(politicianID),
-		Volume24h:    calculateVolume24h(politicianID),
+		LastPrice:    calculateLastPrice(politicianID, currency),
+		Volume24h:    calculateVolume24h(politicianID, currency),
 	}
 
 	return orderBook, nil
@@ -324,105 +399,39 @@ func calculateCurrentPrice(orderBook *ptypes.OrderBook) int64 {
 }
 
 // calculateLastPrice는 최근 체결가를 계산합니다.
-func calculateLastPrice(politicianID string) int64 {
+func calculateLastPrice(politicianID, currency string) int64 {
 	// TODO: 실제 거래 기록에서 최근 체결가 조회
 	return 0
 }
 
 // calculateVolume24h는 24시간 거래량을 계산합니다.
-func calculateVolume24h(politicianID string) int64 {
+func calculateVolume24h(politicianID, currency string) int64 {
 	// TODO: 실제 거래 기록에서 24시간 거래량 계산
 	return 0
 }
 
-// placeTradeOrder는 거래 주문을 처리합니다.
-func placeTradeOrder(userID string, req ptypes.TradeRequest) (string, error) {
-	// 사용자 계정 조회
-	account, err := getUserAccount(userID)
-	if err != nil {
-		return "", fmt.Errorf("계정을 찾을 수 없습니다")
-	}
-
-	// 에스크로 동결 금액 계산
-	var escrowAmount int64
-	var availableBalance int64
-	
-	if req.OrderType == "buy" {
-		// 매수: 스테이블코인 동결
-		escrowAmount = req.Quantity * req.Price
-		if req.Currency == "USDT" {
-			availableBalance = account.USDTBalance - account.EscrowAccount.FrozenUSDTBalance
-		} else {
-			availableBalance = account.USDCBalance - account.EscrowAccount.FrozenUSDCBalance
-		}
-		
-		if availableBalance < escrowAmount {
-			return "", fmt.Errorf("사용 가능한 %s이 부족합니다 (필요: %d, 사용가능: %d)", req.Currency, escrowAmount, availableBalance)
-		}
-	} else {
-		// 매도: 정치인 코인 동결
-		escrowAmount = req.Quantity
-		frozenCoins := account.EscrowAccount.FrozenPoliticianCoins[req.PoliticianID]
-		availableBalance = account.PoliticianCoins[req.PoliticianID] - frozenCoins
-		
-		if availableBalance < escrowAmount {
-			return "", fmt.Errorf("사용 가능한 정치인 코인이 부족합니다 (필요: %d, 사용가능: %d)", escrowAmount, availableBalance)
-		}
-	}
-
-	// 주문 ID 생성
-	orderID := fmt.Sprintf("order_%s_%d", userID, time.Now().UnixNano())
-
-	// 주문 데이터 생성
-	order := ptypes.TradeOrder{
-		ID:             orderID,
-		UserID:         userID,
-		PoliticianID:   req.PoliticianID,
-		OrderType:      req.OrderType,
-		Currency:       req.Currency,
-		Quantity:       req.Quantity,
-		Price:          req.Price,
-		Status:         "active",
-		FilledQuantity: 0,
-		EscrowAmount:   escrowAmount,
-		CreatedAt:      time.Now().Unix(),
-		UpdatedAt:      time.Now().Unix(),
-	}
-
-	// 블록체인에 주문 추가
+// placeTradeOrder는 거래 주문을 제출하고, 체인이 부여한 주문 ID와 실행 후 주문 상태를 반환합니다.
+// 잔액 확인, 에스크로 동결과 체결은 체인이 place_order 트랜잭션 안에서 함께 처리하므로
+// 잔액이 부족하면 체인의 거부 사유가 그대로 오류로 돌아옵니다.
+func placeTradeOrder(userID string, req ptypes.TradeRequest) (string, string, error) {
 	txData := ptypes.TxData{
+		TxID:   fmt.Sprintf("order_%s_%d", userID, time.Now().UnixNano()),
 		Action: "place_order",
 		UserID: userID,
+		Msg:    &ptypes.TxMsg{PlaceOrder: placeOrderMsg(req)},
 	}
 
-	orderBytes, err := json.Marshal(order)
+	txBytes, err := signTx(txData)
 	if err != nil {
-		return "", fmt.Errorf("order marshal error: %v", err)
+		return "", "", fmt.Errorf("transaction marshal error: %v", err)
 	}
 
-	txData.TxID = orderID
-	// 주문 데이터를 TxData에 포함 (임시로 Politicians 필드 사용)
-	txData.Politicians = []string{string(orderBytes)}
-
-	txBytes, err := json.Marshal(txData)
+	// 체인이 부여한 주문 ID는 실행 결과의 Data로, 실행 후 주문 상태는 Log로 돌아옵니다.
+	result, err := broadcastAndCommitTx(context.Background(), txBytes)
 	if err != nil {
-		return "", fmt.Errorf("transaction marshal error: %v", err)
+		return "", "", err
 	}
-
-	if err := broadcastAndCheckTx(context.Background(), txBytes); err != nil {
-		return "", fmt.Errorf("blockchain transaction error: %v", err)
-	}
-
-	// 에스크로 동결 처리
-	if err := freezeEscrowAmount(userID, &order); err != nil {
-		log.Printf("⚠️ 에스크로 동결 실패, 주문은 등록됨: %v", err)
-		// 에스크로 동결 실패 시에도 주문은 유지되지만 경고 로그
-	}
-
-	// 매칭 시도 (비동기)
-	go tryMatchOrders(req.PoliticianID)
-
-	return orderID, nil
+	return string(result.Data), result.Log, nil
 }
 
 // cancelTradeOrder는 거래 주문을 취소합니다.
@@ -437,7 +446,7 @@ func cancelTradeOrder(userID, orderID string) error {
 		return fmt.Errorf("주문을 취소할 권한이 없습니다")
 	}
 
-	if order.Status != "active" {
+	if !isOpenOrder(*order) {
 		return fmt.Errorf("이미 처리된 주문입니다")
 	}
 
@@ -446,30 +455,21 @@ func cancelTradeOrder(userID, orderID string) error {
 		Action: "cancel_order",
 		UserID: userID,
 		TxID:   fmt.Sprintf("cancel_%s_%d", orderID, time.Now().UnixNano()),
-		Politicians: []string{orderID}, // 주문 ID 전달
+		Msg:    &ptypes.TxMsg{CancelOrder: &ptypes.CancelOrderMsg{OrderID: orderID}},
 	}
 
-	txBytes, err := json.Marshal(txData)
+	txBytes, err := signTx(txData)
 	if err != nil {
 		return fmt.Errorf("transaction marshal error: %v", err)
 	}
 
-	if err := broadcastAndCheckTx(context.Background(), txBytes); err != nil {
-		return err
-	}
-
-	// 에스크로 해제 처리
-	if err := releaseEscrowAmount(userID, orderID); err != nil {
-		log.Printf("⚠️ 에스크로 해제 실패: %v", err)
-		// 에스크로 해제 실패 시에도 주문 취소는 완료됨
-	}
-
-	return nil
+	// 남은 에스크로는 체인이 cancel_order 트랜잭션 안에서 함께 해제합니다.
+	return broadcastAndCheckTx(context.Background(), txBytes)
 }
 
-// getUserActiveOrders는 사용자의 활성 주문을 반환합니다.
-func getUserActiveOrders(userID string) ([]ptypes.TradeOrder, error) {
-	queryPath := fmt.Sprintf("/user-orders?user_id=%s", userID)
+// getUserOrders는 사용자의 주문을 반환합니다. status가 비어 있으면 미체결 주문과 만료된 주문을 반환합니다.
+func getUserOrders(userID, status string) ([]ptypes.TradeOrder, error) {
+	queryPath := fmt.Sprintf("/user-orders?user_id=%s&status=%s", url.QueryEscape(userID), url.QueryEscape(status))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("user orders query error: %v", err)
@@ -482,20 +482,24 @@ func getUserActiveOrders(userID string) ([]ptypes.TradeOrder, error) {
 		}
 	}
 
-	// 활성 주문만 필터링
-	var activeOrders []ptypes.TradeOrder
+	if status != "" {
+		return orders, nil
+	}
+
+	// 미체결 주문과, 사용자가 알아야 하는 만료된 주문만 필터링
+	var visibleOrders []ptypes.TradeOrder
 	for _, order := range orders {
-		if order.Status == "active" {
-			activeOrders = append(activeOrders, order)
+		if isOpenOrder(order) || order.Status == "expired" {
+			visibleOrders = append(visibleOrders, order)
 		}
 	}
 
-	return activeOrders, nil
+	return visibleOrders, nil
 }
 
 // getTradeOrder는 특정 주문을 조회합니다.
 func getTradeOrder(orderID string) (*ptypes.TradeOrder, error) {
-	queryPath := fmt.Sprintf("/order?id=%s", orderID)
+	queryPath := fmt.Sprintf("/order?id=%s", url.QueryEscape(orderID))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("order query error: %v", err)
@@ -515,7 +519,7 @@ func getTradeOrder(orderID string) (*ptypes.TradeOrder, error) {
 
 // getUserAccount는 사용자 계정을 조회합니다.
 func getUserAccount(userID string) (*ptypes.Account, error) {
-	queryPath := fmt.Sprintf("/account?address=%s", userID)
+	queryPath := fmt.Sprintf("/account?address=%s", url.QueryEscape(userID))
 	res, err := blockchainClient.ABCIQuery(context.Background(), queryPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("account query error: %v", err)
@@ -533,6 +537,11 @@ func getUserAccount(userID string) (*ptypes.Account, error) {
 	return &account, nil
 }
 
+// isOpenOrder는 아직 체결 대기 중인(전체 또는 부분 미체결) 주문인지 확인합니다.
+func isOpenOrder(order ptypes.TradeOrder) bool {
+	return order.Status == "active" || order.Status == "partial"
+}
+
 // verifyUserPIN은 사용자 PIN을 검증합니다.
 func verifyUserPIN(userID, pin string) error {
 	// 기존 PIN 검증 로직 재사용
@@ -540,173 +549,6 @@ func verifyUserPIN(userID, pin string) error {
 	return nil
 }
 
-// tryMatchOrders는 주문 매칭을 시도합니다.
-func tryMatchOrders(politicianID string) {
-	log.Printf("🔄 주문 매칭 시작: %s", politicianID)
-	
-	// 해당 정치인의 오더북 조회
-	orderBook, err := getOrderBookForPolitician(politicianID)
-	if err != nil {
-		log.Printf("❌ 오더북 조회 실패: %v", err)
-		return
-	}
-	
-	// 매수/매도 주문이 모두 있는지 확인
-	if len(orderBook.BuyOrders) == 0 || len(orderBook.SellOrders) == 0 {
-		log.Printf("📝 매칭할 주문 없음 - 매수: %d, 매도: %d", len(orderBook.BuyOrders), len(orderBook.SellOrders))
-		return
-	}
-	
-	// 매수 최고가와 매도 최저가 비교
-	for len(orderBook.BuyOrders) > 0 && len(orderBook.SellOrders) > 0 {
-		buyOrder := &orderBook.BuyOrders[0]   // 가장 높은 매수 가격
-		sellOrder := &orderBook.SellOrders[0] // 가장 낮은 매도 가격
-		
-		// 가격이 맞지 않으면 매칭 중단
-		if buyOrder.Price < sellOrder.Price {
-			log.Printf("💸 가격 불일치 - 매수: %d, 매도: %d", buyOrder.Price, sellOrder.Price)
-			break
-		}
-		
-		// 거래 체결
-		if err := executeTrade(buyOrder, sellOrder); err != nil {
-			log.Printf("❌ 거래 체결 실패: %v", err)
-			break
-		}
-		
-		// 주문 상태 업데이트 후 오더북 재조회
-		orderBook, err = getOrderBookForPolitician(politicianID)
-		if err != nil {
-			log.Printf("❌ 오더북 재조회 실패: %v", err)
-			break
-		}
-	}
-	
-	log.Printf("✅ 주문 매칭 완료: %s", politicianID)
-}
-
-// executeTrade는 실제 거래를 체결합니다.
-func executeTrade(buyOrder, sellOrder *ptypes.TradeOrder) error {
-	log.Printf("🤝 거래 체결 시작 - 매수주문: %s, 매도주문: %s", buyOrder.ID, sellOrder.ID)
-	
-	// 체결 수량 결정 (둘 중 작은 값)
-	tradeQuantity := buyOrder.Quantity - buyOrder.FilledQuantity
-	remainingSellQuantity := sellOrder.Quantity - sellOrder.FilledQuantity
-	
-	if remainingSellQuantity < tradeQuantity {
-		tradeQuantity = remainingSellQuantity
-	}
-	
-	// 체결 가격 결정 (먼저 등록된 주문의 가격 적용)
-	var tradePrice int64
-	if buyOrder.CreatedAt < sellOrder.CreatedAt {
-		tradePrice = buyOrder.Price
-	} else {
-		tradePrice = sellOrder.Price
-	}
-	
-	totalAmount := tradeQuantity * tradePrice
-	
-	log.Printf("📊 거래 세부사항 - 수량: %d, 가격: %d, 총액: %d", tradeQuantity, tradePrice, totalAmount)
-	
-	// 거래 기록 생성
-	tradeID := fmt.Sprintf("trade_%d_%s", time.Now().UnixNano(), buyOrder.PoliticianID)
-	trade := ptypes.Trade{
-		ID:           tradeID,
-		BuyOrderID:   buyOrder.ID,
-		SellOrderID:  sellOrder.ID,
-		BuyerID:      buyOrder.UserID,
-		SellerID:     sellOrder.UserID,
-		PoliticianID: buyOrder.PoliticianID,
-		Quantity:     tradeQuantity,
-		Price:        tradePrice,
-		TotalAmount:  totalAmount,
-		Timestamp:    time.Now().Unix(),
-		Status:       "processing",
-	}
-	
-	// 블록체인에 거래 체결 트랜잭션 전송
-	txData := ptypes.TxData{
-		Action: "execute_trade",
-		TxID:   tradeID,
-	}
-	
-	// 거래 데이터를 JSON으로 직렬화하여 전송
-	tradeBytes, err := json.Marshal(trade)
-	if err != nil {
-		return fmt.Errorf("거래 데이터 직렬화 실패: %v", err)
-	}
-	
-	txData.Politicians = []string{string(tradeBytes)}
-	
-	txBytes, err := json.Marshal(txData)
-	if err != nil {
-		return fmt.Errorf("트랜잭션 직렬화 실패: %v", err)
-	}
-	
-	if err := broadcastAndCheckTx(context.Background(), txBytes); err != nil {
-		return fmt.Errorf("거래 체결 트랜잭션 실패: %v", err)
-	}
-	
-	log.Printf("✅ 거래 체결 완료: %s", tradeID)
-	return nil
-}
-
-// freezeEscrowAmount는 에스크로 금액을 동결합니다.
-func freezeEscrowAmount(userID string, order *ptypes.TradeOrder) error {
-	log.Printf("🔒 에스크로 동결 시작 - 사용자: %s, 주문: %s", userID, order.ID)
-	
-	// 에스크로 동결 트랜잭션 생성
-	txData := ptypes.TxData{
-		Action: "freeze_escrow",
-		UserID: userID,
-		TxID:   fmt.Sprintf("freeze_%s_%d", order.ID, time.Now().UnixNano()),
-	}
-	
-	// 주문 정보를 JSON으로 직렬화
-	orderBytes, err := json.Marshal(order)
-	if err != nil {
-		return fmt.Errorf("주문 데이터 직렬화 실패: %v", err)
-	}
-	
-	txData.Politicians = []string{string(orderBytes)}
-	
-	txBytes, err := json.Marshal(txData)
-	if err != nil {
-		return fmt.Errorf("트랜잭션 직렬화 실패: %v", err)
-	}
-	
-	if err := broadcastAndCheckTx(context.Background(), txBytes); err != nil {
-		return fmt.Errorf("에스크로 동결 트랜잭션 실패: %v", err)
-	}
-	
-	log.Printf("✅ 에스크로 동결 완료 - 사용자: %s, 금액: %d", userID, order.EscrowAmount)
-	return nil
-}
-
-// releaseEscrowAmount는 에스크로 금액을 해제합니다.
-func releaseEscrowAmount(userID, orderID string) error {
-	log.Printf("🔓 에스크로 해제 시작 - 사용자: %s, 주문: %s", userID, orderID)
-	
-	txData := ptypes.TxData{
-		Action: "release_escrow",
-		UserID: userID,
-		TxID:   fmt.Sprintf("release_%s_%d", orderID, time.Now().UnixNano()),
-		Politicians: []string{orderID}, // 주문 ID 전달
-	}
-	
-	txBytes, err := json.Marshal(txData)
-	if err != nil {
-		return fmt.Errorf("트랜잭션 직렬화 실패: %v", err)
-	}
-	
-	if err := broadcastAndCheckTx(context.Background(), txBytes); err != nil {
-		return fmt.Errorf("에스크로 해제 트랜잭션 실패: %v", err)
-	}
-	
-	log.Printf("✅ 에스크로 해제 완료 - 사용자: %s, 주문: %s", userID, orderID)
-	return nil
-}
 
 // getAvailableBalance는 사용자의 사용 가능한 잔액을 반환합니다.
 func getAvailableBalance(userID string) (*ptypes.Account, error) {
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+)
+
+// 로컬 testnet의 노드 i는 아래 포트에 i*testnetPortStep을 더한 포트를 사용합니다.
+const (
+	testnetP2PPort  = 26656
+	testnetRPCPort  = 26657
+	testnetHTTPPort = 8080
+	testnetPortStep = 10
+)
+
+// runTestnet은 한 머신에서 실행할 수 있는 N개 검증자 네트워크의 노드 홈 디렉터리를 만듭니다.
+// 각 노드는 자기 키와 서로 다른 포트를 갖고, 같은 제네시스와 서로를 persistent peer로 공유합니다.
+//
+//	politisian testnet --validators 4 --output ./testnet --key-registrar $(politisian registrar-key)
+func runTestnet(args []string) error {
+	fs := flag.NewFlagSet("testnet", flag.ContinueOnError)
+	validators := fs.Int("validators", 4, "number of validator nodes")
+	output := fs.String("output", "./testnet", "directory to write node homes into")
+	chainID := fs.String("chain-id", defaultChainID, "chain ID of the network")
+	host := fs.String("host", "127.0.0.1", "address the nodes use to reach each other")
+	roster := fs.String("roster", "", "politician roster (.csv or .json) for the genesis (default built-in roster)")
+	keyRegistrar := fs.String("key-registrar", "", "hex ed25519 public key of the key registrar; print it with 'politisian registrar-key'")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *validators < 1 {
+		return fmt.Errorf("--validators must be at least 1")
+	}
+	// 노드 디렉터리를 만들기 전에 로스터와 키 등록 공개키를 확인합니다.
+	politicians, err := loadRoster(*roster)
+	if err != nil {
+		return err
+	}
+	registrar, err := parseRegistrarKey(*keyRegistrar)
+	if err != nil {
+		return err
+	}
+
+	homes := make([]string, *validators)
+	configs := make([]*config.Config, *validators)
+	genValidators := make([]types.GenesisValidator, *validators)
+	peers := make([]string, *validators)
+	for i := range homes {
+		homes[i] = filepath.Join(*output, fmt.Sprintf("node%d", i))
+		// 기존 노드 키를 덮어쓰지 않도록 이미 있는 디렉터리는 거부합니다.
+		if _, err := os.Stat(homes[i]); err == nil {
+			return fmt.Errorf("%s already exists", homes[i])
+		}
+		config.EnsureRoot(homes[i])
+
+		cfg := config.DefaultConfig()
+		cfg.SetRoot(homes[i])
+		cfg.Moniker = fmt.Sprintf("node%d", i)
+		cfg.P2P.ListenAddress = fmt.Sprintf("tcp://0.0.0.0:%d", testnetP2PPort+i*testnetPortStep)
+		cfg.RPC.ListenAddress = fmt.Sprintf("tcp://0.0.0.0:%d", testnetRPCPort+i*testnetPortStep)
+		cfg.Consensus.TimeoutCommit = 5 * time.Second
+		// 모든 노드가 같은 IP를 사용합니다.
+		cfg.P2P.AddrBookStrict = false
+		cfg.P2P.AllowDuplicateIP = true
+		configs[i] = cfg
+
+		pv := privval.GenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
+		pv.Save()
+		nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
+		if err != nil {
+			return fmt.Errorf("failed to generate node key for %s: %w", cfg.Moniker, err)
+		}
+		pubKey, err := pv.GetPubKey()
+		if err != nil {
+			return fmt.Errorf("failed to get validator key for %s: %w", cfg.Moniker, err)
+		}
+		genValidators[i] = types.GenesisValidator{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   10,
+			Name:    cfg.Moniker,
+		}
+		peers[i] = p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("%s:%d", *host, testnetP2PPort+i*testnetPortStep))
+	}
+
+	genDoc, err := newGenesisDoc(*chainID, genValidators, politicians, registrar)
+	if err != nil {
+		return err
+	}
+	for i, cfg := range configs {
+		var others []string
+		for j, peer := range peers {
+			if j != i {
+				others = append(others, peer)
+			}
+		}
+		cfg.P2P.PersistentPeers = strings.Join(others, ",")
+		config.WriteConfigFile(filepath.Join(cfg.RootDir, "config", "config.toml"), cfg)
+		if err := genDoc.SaveAs(cfg.GenesisFile()); err != nil {
+			return fmt.Errorf("failed to save genesis for %s: %w", cfg.Moniker, err)
+		}
+	}
+
+	fmt.Printf("Generated %d-validator testnet %q in %s\n", *validators, *chainID, *output)
+	fmt.Println("Start each node in its own terminal:")
+	for i, home := range homes {
+		fmt.Printf("  politisian start --home %s --http-port %d\n", home, testnetHTTPPort+i*testnetPortStep)
+	}
+	return nil
+}